@@ -0,0 +1,42 @@
+package rx_go_test
+
+import (
+	"context"
+	"github.com/PxyUp/rx_go"
+)
+
+// collect - read all values of the channel until it is closed
+func collect[T any](ch chan T) []T {
+	var res []T
+	for v := range ch {
+		res = append(res, v)
+	}
+	return res
+}
+
+// collectErr - read all values of new subscription and return them with error of the subscription
+func collectErr[T any](o *rx_go.Observable[T]) ([]T, error) {
+	var res []T
+	sub := o.SubscribeWith(context.Background(), rx_go.Handlers[T]{
+		OnNext: func(value T) {
+			res = append(res, value)
+		},
+	})
+	<-sub.Done()
+	return res, sub.Err()
+}
+
+// subscribeErr - same as Subscribe, channel is closed after subscription finished so error of the subscription can be read
+func subscribeErr[T any](o *rx_go.Observable[T]) (chan T, *rx_go.Subscription) {
+	ch := make(chan T)
+	sub := o.SubscribeWith(context.Background(), rx_go.Handlers[T]{
+		OnNext: func(value T) {
+			ch <- value
+		},
+	})
+	go func() {
+		<-sub.Done()
+		close(ch)
+	}()
+	return ch, sub
+}
@@ -0,0 +1,173 @@
+package rx_go
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrSlowSubscriber - error of subscriber which does not read values of shared observable in time(see NewHttpHandler)
+var ErrSlowSubscriber = errors.New("rx_go: slow subscriber")
+
+// multicast deliver same values to many subscribers, each subscriber has own queue so slow subscriber does not block others
+type multicast[T any] struct {
+	mutex       sync.Mutex
+	subscribers map[*subscriber[T]]struct{}
+	completed   bool
+	err         error
+}
+
+// subscriber - queue is not limited if limit is zero, values are kept until observer read them so slow subscriber increase memory usage.
+// Subscriber with full queue is completed with ErrSlowSubscriber
+type subscriber[T any] struct {
+	observer *Observer[T]
+	signal   chan struct{}
+	limit    int
+
+	mutex     sync.Mutex
+	queue     []T
+	completed bool
+	err       error
+}
+
+func newMulticast[T any]() *multicast[T] {
+	return &multicast[T]{
+		subscribers: map[*subscriber[T]]struct{}{},
+	}
+}
+
+// subscribe register new subscriber, initial values are emitted before all next values
+func (m *multicast[T]) subscribe(initial ...T) *Observer[T] {
+	return m.add(&subscriber[T]{
+		observer: NewObserver[T](),
+		signal:   make(chan struct{}, 1),
+		queue:    initial,
+	})
+}
+
+// subscribeLimit register new subscriber which is completed with ErrSlowSubscriber if more than limit values wait in its queue
+func (m *multicast[T]) subscribeLimit(limit int) *Observer[T] {
+	return m.add(&subscriber[T]{
+		observer: NewObserver[T](),
+		signal:   make(chan struct{}, 1),
+		limit:    limit,
+	})
+}
+
+func (m *multicast[T]) add(s *subscriber[T]) *Observer[T] {
+
+	m.mutex.Lock()
+	if m.completed {
+		s.completed = true
+		s.err = m.err
+	} else {
+		m.subscribers[s] = struct{}{}
+	}
+	m.mutex.Unlock()
+
+	go func() {
+		<-s.observer.done
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		delete(m.subscribers, s)
+	}()
+
+	go s.run()
+
+	return s.observer
+}
+
+func (m *multicast[T]) next(value T) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for s := range m.subscribers {
+		s.push(value)
+	}
+}
+
+func (m *multicast[T]) complete(err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.completed {
+		return
+	}
+	m.completed = true
+	m.err = err
+	for s := range m.subscribers {
+		s.finish(err)
+	}
+	m.subscribers = map[*subscriber[T]]struct{}{}
+}
+
+// isCompleted - return true if multicast completed and new subscribers are completed immediately
+func (m *multicast[T]) isCompleted() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.completed
+}
+
+// terminalErr - return error which completed multicast
+func (m *multicast[T]) terminalErr() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.err
+}
+
+func (s *subscriber[T]) push(value T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.completed {
+		return
+	}
+	if s.limit > 0 && len(s.queue) >= s.limit {
+		s.completed = true
+		s.err = ErrSlowSubscriber
+		s.notify()
+		return
+	}
+	s.queue = append(s.queue, value)
+	s.notify()
+}
+
+func (s *subscriber[T]) finish(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.completed {
+		return
+	}
+	s.completed = true
+	s.err = err
+	s.notify()
+}
+
+func (s *subscriber[T]) notify() {
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+// run deliver queued values to the observer until subscriber finished or observer completed by unsubscribe
+func (s *subscriber[T]) run() {
+	for {
+		s.mutex.Lock()
+		queue := s.queue
+		s.queue = nil
+		completed, err := s.completed, s.err
+		s.mutex.Unlock()
+
+		for _, value := range queue {
+			s.observer.Next(value)
+		}
+
+		if completed {
+			s.observer.complete(err)
+			return
+		}
+
+		select {
+		case <-s.signal:
+		case <-s.observer.done:
+			return
+		}
+	}
+}
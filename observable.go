@@ -1,8 +1,10 @@
 package rx_go
 
 import (
+	"context"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -20,6 +22,15 @@ var (
 	Never = New(NewObserver[any]())
 )
 
+// Throw - create new observable which just completed with error
+func Throw[T any](err error) *Observable[T] {
+	obs := NewObserver[T]()
+	go func() {
+		obs.Error(err)
+	}()
+	return New(obs)
+}
+
 // New create new observable with predefined observer
 func New[T any](observer *Observer[T]) *Observable[T] {
 	return &Observable[T]{
@@ -27,6 +38,46 @@ func New[T any](observer *Observer[T]) *Observable[T] {
 	}
 }
 
+// Defer - create cold observable, factory is called for each subscription so every subscriber receive own sequence.
+// Panic of factory completes the subscription with PanicError
+func Defer[T any](factory func() *Observable[T]) *Observable[T] {
+	return &Observable[T]{
+		factory: func(ctx context.Context) (observer *Observer[T]) {
+			failed := NewObserver[T]()
+			defer func() {
+				if observer == nil {
+					observer = failed
+				}
+			}()
+			defer failed.recoverPanic(nil)
+			return factory().source(ctx)
+		},
+	}
+}
+
+// Emitter - producer side of observable created by Create
+type Emitter[T any] interface {
+	Next(value T)
+	Error(err error)
+	Complete()
+}
+
+// Create - create cold observable, producer is called for each subscription with context of the subscription.
+// Observable completes after producer returns if producer did not complete it before
+func Create[T any](producer func(ctx context.Context, emitter Emitter[T])) *Observable[T] {
+	return &Observable[T]{
+		factory: func(ctx context.Context) *Observer[T] {
+			obs := NewObserver[T]()
+			go func() {
+				defer obs.Complete()
+				defer obs.recoverPanic(nil)
+				producer(ctx, obs)
+			}()
+			return obs
+		},
+	}
+}
+
 // FromChannel create new observable from readable channel
 func FromChannel[T any](ch <-chan T) *Observable[T] {
 	return New[T](ChannelObserver(ch))
@@ -39,23 +90,29 @@ func Of[T any](item T) *Observable[T] {
 
 // Concat create static observable witch emit single array of all values
 func Concat[T any](o *Observable[T]) *Observable[[]T] {
-	obs := NewObserver[[]T]()
+	return lazy(o.cold(), func(ctx context.Context) *Observer[[]T] {
+		obs := NewObserver[[]T]()
 
-	ch, cancel := o.Subscribe()
-	obs.SetOnComplete(func() {
-		cancel()
-	})
+		ch, source, cancel := o.subscribe(ctx)
+		obs.SetOnComplete(func() {
+			cancel()
+		})
 
-	var res []T
-	go func() {
-		for v := range ch {
-			res = append(res, v)
-		}
-		obs.Next(res)
-		obs.Complete()
-	}()
+		var res []T
+		go func() {
+			for v := range ch {
+				res = append(res, v)
+			}
+			if err := source.Err(); err != nil {
+				obs.Error(err)
+				return
+			}
+			obs.Next(res)
+			obs.Complete()
+		}()
 
-	return New(obs)
+		return obs
+	})
 }
 
 // From create new observable from static array
@@ -68,7 +125,7 @@ func NewInterval(duration time.Duration, startNow bool) *Observable[time.Time] {
 	return New[time.Time](IntervalObserver(duration, startNow))
 }
 
-// NewHttp - return Observable from HttpObserver
+// NewHttp - return Observable from HttpObserver, request is executed immediately(see NewHttpResponse for lazy version)
 func NewHttp(client *http.Client, req *http.Request) (*Observable[[]byte], error) {
 	obs, err := HttpObserver(client, req)
 	if err != nil {
@@ -78,139 +135,190 @@ func NewHttp(client *http.Client, req *http.Request) (*Observable[[]byte], error
 }
 
 // Switch change stream for each value
+//
+// Deprecated: Switch waits for completion of each inner observable, use ConcatMap(same behaviour) or SwitchMap
 func Switch[T any, Y any](o *Observable[T], mapper func(T) *Observable[Y]) *Observable[Y] {
-	obs := NewObserver[Y]()
-	var cancelFns []func()
+	return ConcatMap(o, mapper)
+}
 
-	obs.SetOnComplete(func() {
-		for _, fn := range cancelFns {
-			fn()
-		}
+// ConcatMap - emit all values of observable returned by mapper for each value, next value is mapped only after previous observable completed
+func ConcatMap[T any, Y any](o *Observable[T], mapper func(T) *Observable[Y]) *Observable[Y] {
+	return lazy(o.cold(), func(ctx context.Context) *Observer[Y] {
+		obs := NewObserver[Y]()
+		inner := newInnerSubscriptions(obs)
+
+		ch, source, cancel := o.subscribe(ctx)
+		obs.SetOnComplete(func() {
+			cancel()
+			inner.stop()
+		})
+
+		go func() {
+			defer obs.recoverPanic(nil)
+
+			for value := range ch {
+				inner.start(mapper(value), nil)
+				inner.wait()
+			}
+			obs.completeWith(source.Err)
+		}()
+
+		return obs
 	})
+}
 
-	go func() {
-		topCh, cancelTop := o.Subscribe()
-		cancelFns = append(cancelFns, cancelTop)
+// SwitchMap - emit values of observable returned by mapper for latest value, previous inner observable is unsubscribed when next value is emitted.
+// Observable completes after source and latest inner observable completed
+func SwitchMap[T any, Y any](o *Observable[T], mapper func(T) *Observable[Y]) *Observable[Y] {
+	return lazy(o.cold(), func(ctx context.Context) *Observer[Y] {
+		obs := NewObserver[Y]()
+		inner := newInnerSubscriptions(obs)
 
-		for oldValue := range topCh {
-			localValue := oldValue
-			ch, cancel := mapper(localValue).Subscribe()
-			cancelFns = append(cancelFns, cancel)
-			for v := range ch {
-				obs.Next(v)
+		ch, source, cancel := o.subscribe(ctx)
+		obs.SetOnComplete(func() {
+			cancel()
+			inner.stop()
+		})
+
+		go func() {
+			defer obs.recoverPanic(nil)
+
+			for value := range ch {
+				inner.cancelAll()
+				inner.start(mapper(value), nil)
 			}
-		}
+			if err := source.Err(); err != nil {
+				obs.Error(err)
+				return
+			}
+			inner.wait()
+			obs.Complete()
+		}()
 
-		obs.Complete()
-	}()
-	return New(obs)
+		return obs
+	})
 }
 
 // ForkJoin - wait for Observables to complete and then combine last values they emitted; complete immediately if an empty array is passed.
 func ForkJoin[T any](obss ...*Observable[T]) *Observable[[]T] {
-	obs := NewObserver[[]T]()
-	resp := make([]T, len(obss))
-	clean := make(chan struct{})
-	cleanFns := make([]func(), len(obss))
-	obs.SetOnComplete(func() {
-		close(clean)
-		for _, v := range cleanFns {
-			v()
+	return lazy(anyCold(obss), func(ctx context.Context) *Observer[[]T] {
+		obs := NewObserver[[]T]()
+		resp := make([]T, len(obss))
+		cleanFns := make([]func(), len(obss))
+		clean := onCompleteOnce(obs, cleanFns)
+
+		chs := make([]chan T, len(obss))
+		sources := make([]*Observer[T], len(obss))
+		for i, o := range obss {
+			chs[i], sources[i], cleanFns[i] = o.Pipe(LastOne[T]()).subscribe(ctx)
 		}
-	})
 
-	var wg sync.WaitGroup
-	for i, o := range obss {
-		wg.Add(1)
-		go func(index int, ob *Observable[T]) {
-			defer wg.Done()
-			ch, cancel := ob.Pipe(LastOne[T]()).Subscribe()
-			cleanFns[index] = cancel
+		var wg sync.WaitGroup
+		for i := range obss {
+			wg.Add(1)
+			go func(index int, ch chan T, source *Observer[T]) {
+				defer wg.Done()
 
-			for {
-				select {
-				case <-clean:
-					return
-				default:
-					value, ok := <-ch
-					if !ok {
+				for {
+					select {
+					case <-clean:
 						return
+					default:
+						value, ok := <-ch
+						if !ok {
+							if err := source.Err(); err != nil {
+								obs.Error(err)
+							}
+							return
+						}
+						resp[index] = value
 					}
-					resp[index] = value
 				}
-			}
-		}(i, o)
-	}
+			}(i, chs[i], sources[i])
+		}
 
-	go func() {
-		wg.Wait()
-		obs.Next(resp)
-		obs.Complete()
-	}()
+		go func() {
+			wg.Wait()
+			obs.Next(resp)
+			obs.Complete()
+		}()
 
-	return New(obs)
+		return obs
+	})
 }
 
 // Pairwise - groups pairs of consecutive emissions together and emits them as an array of two values.
 func Pairwise[T any](o *Observable[T]) *Observable[[2]T] {
-	obs := NewObserver[[2]T]()
+	return lazy(o.cold(), func(ctx context.Context) *Observer[[2]T] {
+		obs := NewObserver[[2]T]()
 
-	go func() {
-		defer obs.Complete()
-		ch, cancel := o.Subscribe()
+		ch, source, cancel := o.subscribe(ctx)
 		obs.SetOnComplete(func() {
 			cancel()
 		})
 
-		var prev *T
-		for value := range ch {
-			local := value
-			if prev != nil {
-				obs.Next([2]T{*prev, local})
+		go func() {
+			defer obs.completeWith(source.Err)
+
+			var prev *T
+			for value := range ch {
+				local := value
+				if prev != nil {
+					obs.Next([2]T{*prev, local})
+				}
+				prev = &local
 			}
-			prev = &local
-		}
-	}()
+		}()
 
-	return New(obs)
+		return obs
+	})
 }
 
 // Reduce - create new observable which return accumulation value from all previous emitted items
 func Reduce[T any, Y any](o *Observable[T], mapper func(Y, T) Y, initValue Y) *Observable[Y] {
-	obs := NewObserver[Y]()
+	return lazy(o.cold(), func(ctx context.Context) *Observer[Y] {
+		obs := NewObserver[Y]()
 
-	go func() {
-		defer obs.Complete()
-		ch, cancel := o.Subscribe()
+		ch, source, cancel := o.subscribe(ctx)
 		obs.SetOnComplete(func() {
 			cancel()
 		})
-		iValue := initValue
-		for value := range ch {
-			iValue = mapper(iValue, value)
-			obs.Next(iValue)
-		}
-	}()
 
-	return New(obs)
+		go func() {
+			defer obs.completeWith(source.Err)
+			defer obs.recoverPanic(nil)
+			iValue := initValue
+			for value := range ch {
+				iValue = mapper(iValue, value)
+				obs.Next(iValue)
+			}
+		}()
+
+		return obs
+	})
 }
 
 // MapTo create new observable with modified values
 func MapTo[T any, Y any](o *Observable[T], mapper func(T) Y) *Observable[Y] {
-	obs := NewObserver[Y]()
-	go func() {
-		defer obs.Complete()
-		ch, cancel := o.Subscribe()
+	return lazy(o.cold(), func(ctx context.Context) *Observer[Y] {
+		obs := NewObserver[Y]()
+
+		ch, source, cancel := o.subscribe(ctx)
 		obs.SetOnComplete(func() {
 			cancel()
 		})
 
-		for value := range ch {
-			obs.Next(mapper(value))
-		}
-	}()
+		go func() {
+			defer obs.completeWith(source.Err)
+			defer obs.recoverPanic(nil)
 
-	return New(obs)
+			for value := range ch {
+				obs.Next(mapper(value))
+			}
+		}()
+
+		return obs
+	})
 }
 
 // BroadCast splitting output from the observable to many outputs
@@ -222,12 +330,12 @@ func BroadCast[T any](obs *Observable[T], size int) []*Observable[T] {
 		obss[i] = New(oo[i])
 	}
 	go func() {
-		ch, cancel := obs.Subscribe()
+		ch, source, cancel := obs.subscribe(context.Background())
 
 		defer func() {
 			for i := 0; i < size; i++ {
 				go func(lI int) {
-					oo[lI].Complete()
+					oo[lI].completeWith(source.Err)
 				}(i)
 			}
 		}()
@@ -256,43 +364,477 @@ func BroadCast[T any](obs *Observable[T], size int) []*Observable[T] {
 
 // Merge merging multi observables with same type into single one
 func Merge[T any](obss ...*Observable[T]) *Observable[T] {
-	observer := NewObserver[T]()
-	cleanFns := make([]func(), len(obss))
-	clean := make(chan struct{})
-	observer.SetOnComplete(func() {
-		close(clean)
-		for _, v := range cleanFns {
-			v()
+	return lazy(anyCold(obss), func(ctx context.Context) *Observer[T] {
+		observer := NewObserver[T]()
+		cleanFns := make([]func(), len(obss))
+		clean := onCompleteOnce(observer, cleanFns)
+
+		chs := make([]chan T, len(obss))
+		sources := make([]*Observer[T], len(obss))
+		for i, o := range obss {
+			chs[i], sources[i], cleanFns[i] = o.subscribe(ctx)
+		}
+
+		var wg sync.WaitGroup
+		for i := range obss {
+			wg.Add(1)
+			go func(ch chan T, source *Observer[T]) {
+				defer wg.Done()
+
+				for {
+					select {
+					case <-clean:
+						return
+					default:
+						value, ok := <-ch
+						if !ok {
+							if err := source.Err(); err != nil {
+								observer.Error(err)
+							}
+							return
+						}
+						observer.Next(value)
+					}
+				}
+			}(chs[i], sources[i])
+		}
+
+		go func() {
+			wg.Wait()
+			observer.Complete()
+		}()
+
+		return observer
+	})
+}
+
+// Race - mirror first observable which emit value or completes, other observables are unsubscribed
+func Race[T any](obss ...*Observable[T]) *Observable[T] {
+	return lazy(anyCold(obss), func(ctx context.Context) *Observer[T] {
+		observer := NewObserver[T]()
+		cleanFns := make([]func(), len(obss))
+		clean := onCompleteOnce(observer, cleanFns)
+
+		if len(obss) == 0 {
+			observer.Complete()
+			return observer
+		}
+
+		chs := make([]chan T, len(obss))
+		sources := make([]*Observer[T], len(obss))
+		for i, o := range obss {
+			chs[i], sources[i], cleanFns[i] = o.subscribe(ctx)
+		}
+
+		var winner int32 = -1
+		// win - return true if observable with index is the winner, losers are unsubscribed by the first call
+		win := func(index int) bool {
+			if atomic.CompareAndSwapInt32(&winner, -1, int32(index)) {
+				for i, cancel := range cleanFns {
+					if i != index {
+						cancel()
+					}
+				}
+				return true
+			}
+			return atomic.LoadInt32(&winner) == int32(index)
+		}
+
+		for i := range obss {
+			go func(index int, ch chan T, source *Observer[T]) {
+				for {
+					select {
+					case <-clean:
+						return
+					case value, ok := <-ch:
+						if !win(index) {
+							return
+						}
+						if !ok {
+							observer.completeWith(source.Err)
+							return
+						}
+						observer.Next(value)
+					}
+				}
+			}(i, chs[i], sources[i])
 		}
+
+		return observer
 	})
+}
+
+// CombineLatest - emit array of latest values of all observables each time any of them emit value, first array is emitted after all observables emitted at least one value.
+// Complete after all observables completed or if any of them completed without values
+func CombineLatest[T any](obss ...*Observable[T]) *Observable[[]T] {
+	return lazy(anyCold(obss), func(ctx context.Context) *Observer[[]T] {
+		observer := NewObserver[[]T]()
+		cleanFns := make([]func(), len(obss))
+		clean := onCompleteOnce(observer, cleanFns)
+
+		if len(obss) == 0 {
+			observer.Complete()
+			return observer
+		}
+
+		chs := make([]chan T, len(obss))
+		sources := make([]*Observer[T], len(obss))
+		for i, o := range obss {
+			chs[i], sources[i], cleanFns[i] = o.subscribe(ctx)
+		}
+
+		var mutex sync.Mutex
+		latest := make([]T, len(obss))
+		has := make([]bool, len(obss))
+		count := 0
+
+		var wg sync.WaitGroup
+		for i := range obss {
+			wg.Add(1)
+			go func(index int, ch chan T, source *Observer[T]) {
+				defer wg.Done()
+
+				emitted := false
+				for {
+					select {
+					case <-clean:
+						return
+					case value, ok := <-ch:
+						if !ok {
+							if err := source.Err(); err != nil {
+								observer.Error(err)
+							} else if !emitted {
+								observer.Complete()
+							}
+							return
+						}
+
+						emitted = true
+						mutex.Lock()
+						latest[index] = value
+						if !has[index] {
+							has[index] = true
+							count++
+						}
+						if count == len(latest) {
+							values := make([]T, len(latest))
+							copy(values, latest)
+							// emit under lock so arrays are received in the same order as they were combined
+							observer.Next(values)
+						}
+						mutex.Unlock()
+					}
+				}
+			}(i, chs[i], sources[i])
+		}
+
+		go func() {
+			wg.Wait()
+			observer.Complete()
+		}()
+
+		return observer
+	})
+}
+
+// CombineLatest2 - same as CombineLatest for two observables with different types
+func CombineLatest2[A any, B any](a *Observable[A], b *Observable[B]) *Observable[Tuple2[A, B]] {
+	return MapTo(CombineLatest(toAny(a), toAny(b)), func(values []any) Tuple2[A, B] {
+		return Tuple2[A, B]{V1: fromAny[A](values[0]), V2: fromAny[B](values[1])}
+	})
+}
+
+// CombineLatest3 - same as CombineLatest for three observables with different types
+func CombineLatest3[A any, B any, C any](a *Observable[A], b *Observable[B], c *Observable[C]) *Observable[Tuple3[A, B, C]] {
+	return MapTo(CombineLatest(toAny(a), toAny(b), toAny(c)), func(values []any) Tuple3[A, B, C] {
+		return Tuple3[A, B, C]{V1: fromAny[A](values[0]), V2: fromAny[B](values[1]), V3: fromAny[C](values[2])}
+	})
+}
+
+// CombineLatest4 - same as CombineLatest for four observables with different types
+func CombineLatest4[A any, B any, C any, D any](a *Observable[A], b *Observable[B], c *Observable[C], d *Observable[D]) *Observable[Tuple4[A, B, C, D]] {
+	return MapTo(CombineLatest(toAny(a), toAny(b), toAny(c), toAny(d)), func(values []any) Tuple4[A, B, C, D] {
+		return Tuple4[A, B, C, D]{V1: fromAny[A](values[0]), V2: fromAny[B](values[1]), V3: fromAny[C](values[2]), V4: fromAny[D](values[3])}
+	})
+}
+
+// zipBufferSize - amount of values buffered for each observable of ZipAll, observable is not read while its buffer is full
+const zipBufferSize = 16
+
+// ZipAll - emit array of i-th values of all observables, complete when shortest observable completed
+func ZipAll[T any](obss ...*Observable[T]) *Observable[[]T] {
+	return lazy(anyCold(obss), func(ctx context.Context) *Observer[[]T] {
+		observer := NewObserver[[]T]()
+		cleanFns := make([]func(), len(obss))
+		clean := onCompleteOnce(observer, cleanFns)
+
+		if len(obss) == 0 {
+			observer.Complete()
+			return observer
+		}
+
+		bufs := make([]chan T, len(obss))
+		// ends - receive index of observable after its buffer is closed
+		ends := make(chan int, len(obss))
+		chs := make([]chan T, len(obss))
+		sources := make([]*Observer[T], len(obss))
+		for i, o := range obss {
+			chs[i], sources[i], cleanFns[i] = o.subscribe(ctx)
+			bufs[i] = make(chan T, zipBufferSize)
+		}
 
-	var wg sync.WaitGroup
-	for i, o := range obss {
-		wg.Add(1)
-		go func(index int, ob *Observable[T]) {
-			defer wg.Done()
-			ch, cancel := ob.Subscribe()
-			cleanFns[index] = cancel
+		for i := range obss {
+			go func(index int, ch chan T, source *Observer[T]) {
+				defer func() {
+					close(bufs[index])
+					ends <- index
+				}()
+
+				for {
+					select {
+					case <-clean:
+						return
+					case value, ok := <-ch:
+						if !ok {
+							if err := source.Err(); err != nil {
+								observer.Error(err)
+							}
+							return
+						}
+						select {
+						case bufs[index] <- value:
+						case <-clean:
+							return
+						}
+					}
+				}
+			}(i, chs[i], sources[i])
+		}
+
+		go func() {
+			defer observer.Complete()
 
 			for {
+				values := make([]T, len(bufs))
+				for i := 0; i < len(bufs); {
+					select {
+					case <-clean:
+						return
+					case value, ok := <-bufs[i]:
+						if !ok {
+							return
+						}
+						values[i] = value
+						i++
+					case index := <-ends:
+						// completed observable which is not read in this round yet and has empty buffer will not produce next array
+						if index > i && len(bufs[index]) == 0 {
+							return
+						}
+					}
+				}
+				observer.Next(values)
+			}
+		}()
+
+		return observer
+	})
+}
+
+// Zip2 - same as ZipAll for two observables with different types
+func Zip2[A any, B any](a *Observable[A], b *Observable[B]) *Observable[Tuple2[A, B]] {
+	return MapTo(ZipAll(toAny(a), toAny(b)), func(values []any) Tuple2[A, B] {
+		return Tuple2[A, B]{V1: fromAny[A](values[0]), V2: fromAny[B](values[1])}
+	})
+}
+
+// Zip3 - same as ZipAll for three observables with different types
+func Zip3[A any, B any, C any](a *Observable[A], b *Observable[B], c *Observable[C]) *Observable[Tuple3[A, B, C]] {
+	return MapTo(ZipAll(toAny(a), toAny(b), toAny(c)), func(values []any) Tuple3[A, B, C] {
+		return Tuple3[A, B, C]{V1: fromAny[A](values[0]), V2: fromAny[B](values[1]), V3: fromAny[C](values[2])}
+	})
+}
+
+// WithLatestFrom - emit result of combine for each value of observable and latest value of other observable, values are dropped until other observable emitted value
+func WithLatestFrom[T any, Y any, R any](o *Observable[T], other *Observable[Y], combine func(T, Y) R) *Observable[R] {
+	return lazy(o.cold() || other.cold(), func(ctx context.Context) *Observer[R] {
+		obs := NewObserver[R]()
+
+		ch, source, cancel := o.subscribe(ctx)
+		otherCh, otherSource, otherCancel := other.subscribe(ctx)
+		obs.SetOnComplete(func() {
+			cancel()
+			otherCancel()
+		})
+
+		var mutex sync.Mutex
+		var latest *Y
+		go func() {
+			for value := range otherCh {
+				local := value
+				mutex.Lock()
+				latest = &local
+				mutex.Unlock()
+			}
+			if err := otherSource.Err(); err != nil {
+				obs.Error(err)
+			}
+		}()
+
+		go func() {
+			defer obs.completeWith(source.Err)
+			defer obs.recoverPanic(nil)
+
+			for value := range ch {
+				mutex.Lock()
+				current := latest
+				mutex.Unlock()
+				if current == nil {
+					continue
+				}
+				obs.Next(combine(value, *current))
+			}
+		}()
+
+		return obs
+	})
+}
+
+// Sequence - emit all values of observables one after the other, next observable is subscribed only after previous completed.
+// Observable completes with error of the first failed observable, after error or unsubscribe current observable is unsubscribed and pending ones are discarded
+func Sequence[T any](obss ...*Observable[T]) *Observable[T] {
+	return lazy(anyCold(obss), func(ctx context.Context) *Observer[T] {
+		observer := NewObserver[T]()
+
+		go func() {
+			next := 0
+			defer func() {
+				for _, o := range obss[next:] {
+					o.discard()
+				}
+			}()
+
+			for next < len(obss) {
 				select {
-				case <-clean:
+				case <-observer.done:
 					return
 				default:
-					value, ok := <-ch
-					if !ok {
+				}
+
+				o := obss[next]
+				next++
+				if err := follow(observer, o); err != nil {
+					observer.Error(err)
+					return
+				}
+			}
+			observer.Complete()
+		}()
+
+		return observer
+	})
+}
+
+// MergeMap - emit values of observables returned by mapper for each value, at most concurrency observables are subscribed at the same time(no limit if less than 1).
+// Next value is not read until one of active observables completed
+func MergeMap[T any, Y any](o *Observable[T], mapper func(T) *Observable[Y], concurrency int) *Observable[Y] {
+	return lazy(o.cold(), func(ctx context.Context) *Observer[Y] {
+		obs := NewObserver[Y]()
+		inner := newInnerSubscriptions(obs)
+
+		ch, source, cancel := o.subscribe(ctx)
+		obs.SetOnComplete(func() {
+			cancel()
+			inner.stop()
+		})
+
+		var sem chan struct{}
+		if concurrency >= 1 {
+			sem = make(chan struct{}, concurrency)
+		}
+
+		go func() {
+			defer obs.recoverPanic(nil)
+
+			for {
+				var release func()
+				if sem != nil {
+					select {
+					case sem <- struct{}{}:
+					case <-obs.done:
 						return
 					}
-					observer.Next(value)
+					release = func() {
+						<-sem
+					}
+				}
+
+				value, ok := <-ch
+				if !ok {
+					break
 				}
+				inner.start(mapper(value), release)
 			}
-		}(i, o)
-	}
 
-	go func() {
-		wg.Wait()
-		observer.Complete()
-	}()
+			if err := source.Err(); err != nil {
+				obs.Error(err)
+				return
+			}
+			inner.wait()
+			obs.Complete()
+		}()
+
+		return obs
+	})
+}
+
+// ExhaustMap - emit values of observable returned by mapper, values are dropped(mapper is not called) while previous inner observable is active.
+// Observable completes after source and active inner observable completed
+func ExhaustMap[T any, Y any](o *Observable[T], mapper func(T) *Observable[Y]) *Observable[Y] {
+	return lazy(o.cold(), func(ctx context.Context) *Observer[Y] {
+		obs := NewObserver[Y]()
+		inner := newInnerSubscriptions(obs)
+
+		ch, source, cancel := o.subscribe(ctx)
+		obs.SetOnComplete(func() {
+			cancel()
+			inner.stop()
+		})
+
+		go func() {
+			defer obs.recoverPanic(nil)
+
+			for value := range ch {
+				if inner.active() > 0 {
+					continue
+				}
+				inner.start(mapper(value), nil)
+			}
+			if err := source.Err(); err != nil {
+				obs.Error(err)
+				return
+			}
+			inner.wait()
+			obs.Complete()
+		}()
 
-	return New(observer)
+		return obs
+	})
+}
+
+// onCompleteOnce - set hook which close returned channel and call cleanFns after observer completed.
+// Hook can be called again by Pipe of the result observable, so it is executed only once.
+// cleanFns should be filled before reading of observables is started, otherwise failed observable can complete observer before next ones are subscribed
+func onCompleteOnce[T any](observer *Observer[T], cleanFns []func()) <-chan struct{} {
+	clean := make(chan struct{})
+	var once sync.Once
+	observer.SetOnComplete(func() {
+		once.Do(func() {
+			close(clean)
+			for _, v := range cleanFns {
+				v()
+			}
+		})
+	})
+	return clean
 }
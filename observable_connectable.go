@@ -0,0 +1,127 @@
+package rx_go
+
+import (
+	"context"
+	"sync"
+)
+
+// ConnectableObservable - observable which share single subscription to the source between all subscribers, source is subscribed only after Connect
+type ConnectableObservable[T any] struct {
+	*Observable[T]
+
+	upstream  *Observable[T]
+	multicast *multicast[T]
+
+	mutex      sync.Mutex
+	connection context.Context
+	disconnect func()
+}
+
+// Publish - create ConnectableObservable from the observable, every subscriber receive all values emitted after Connect.
+// After source completed new subscriber wait for next Connect which subscribe to the source again
+func (o *Observable[T]) Publish() *ConnectableObservable[T] {
+	c := &ConnectableObservable[T]{
+		upstream:  o,
+		multicast: newMulticast[T](),
+	}
+	c.Observable = &Observable[T]{
+		factory: func(ctx context.Context) *Observer[T] {
+			return c.current(true).subscribe()
+		},
+	}
+	return c
+}
+
+// Share - same as Publish().RefCount()
+func (o *Observable[T]) Share() *Observable[T] {
+	return o.Publish().RefCount()
+}
+
+// Connect - subscribe to the source and return function for disconnecting, if already connected same disconnect function is returned
+func (c *ConnectableObservable[T]) Connect() func() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.disconnect != nil {
+		return c.disconnect
+	}
+	if c.multicast.isCompleted() {
+		c.multicast = newMulticast[T]()
+	}
+	m := c.multicast
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, source, _ := c.upstream.subscribe(ctx)
+
+	c.connection = ctx
+	c.disconnect = func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		if c.connection == ctx {
+			c.connection = nil
+			c.disconnect = nil
+		}
+		cancel()
+	}
+
+	go func() {
+		for value := range ch {
+			m.next(value)
+		}
+		// source was disconnected, subscribers still wait for next connection
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		if c.connection == ctx {
+			c.connection = nil
+			c.disconnect = nil
+		}
+		m.complete(source.Err())
+	}()
+
+	return c.disconnect
+}
+
+// current - return multicast for new subscriber, completed multicast is replaced with new one if reset is true
+func (c *ConnectableObservable[T]) current(reset bool) *multicast[T] {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if reset && c.multicast.isCompleted() {
+		c.multicast = newMulticast[T]()
+	}
+	return c.multicast
+}
+
+// RefCount - return observable which connect on first subscription and disconnect when last subscriber unsubscribed,
+// subscription after source completed connect to the source again
+func (c *ConnectableObservable[T]) RefCount() *Observable[T] {
+	var mutex sync.Mutex
+	count := 0
+	var disconnect func()
+
+	return &Observable[T]{
+		factory: func(ctx context.Context) *Observer[T] {
+			observer := c.current(true).subscribe()
+
+			mutex.Lock()
+			count++
+			// Connect return active connection or reconnect if source already completed
+			disconnect = c.Connect()
+			mutex.Unlock()
+
+			go func() {
+				<-observer.done
+				mutex.Lock()
+				defer mutex.Unlock()
+				count--
+				if count == 0 {
+					disconnect()
+				}
+			}()
+
+			return observer
+		},
+	}
+}
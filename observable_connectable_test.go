@@ -0,0 +1,131 @@
+package rx_go_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/rx_go"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestObservable_Publish(t *testing.T) {
+	size := 3
+	obs := rx_go.From([]int{1, 2, 3}...).Publish()
+	chs := make([]chan int, size)
+	for i := 0; i < size; i++ {
+		chs[i], _ = obs.Subscribe()
+	}
+	obs.Connect()
+
+	var wg sync.WaitGroup
+	wg.Add(size)
+	for i := 0; i < size; i++ {
+		go func(lIndex int) {
+			defer wg.Done()
+			var res []int
+			for v := range chs[lIndex] {
+				res = append(res, v)
+			}
+			assert.Equal(t, []int{1, 2, 3}, res)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestObservable_Publish_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.Throw[int](err).Publish()
+	sub := obs.SubscribeWith(context.Background(), rx_go.Handlers[int]{})
+	obs.Connect()
+	<-sub.Done()
+	assert.Equal(t, err, sub.Err())
+
+	// late subscriber wait for next connection
+	sub = obs.SubscribeWith(context.Background(), rx_go.Handlers[int]{})
+	select {
+	case <-sub.Done():
+		t.Fatal("late subscriber was completed before Connect")
+	case <-time.After(time.Millisecond * 50):
+	}
+	obs.Connect()
+	<-sub.Done()
+	assert.Equal(t, err, sub.Err())
+}
+
+func TestObservable_Publish_Reconnect(t *testing.T) {
+	obs := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		for i := 0; i < 3; i++ {
+			emitter.Next(i)
+		}
+	}).Publish()
+
+	for i := 0; i < 2; i++ {
+		ch, _ := obs.Subscribe()
+		obs.Connect()
+		assert.Equal(t, []int{0, 1, 2}, collect(ch))
+	}
+}
+
+func TestConnectableObservable_RefCount(t *testing.T) {
+	subscriptions := 0
+	obs := rx_go.Defer(func() *rx_go.Observable[time.Time] {
+		subscriptions++
+		return rx_go.NewInterval(time.Millisecond*50, true)
+	}).Publish().RefCount()
+
+	ch1, cancel1 := obs.Subscribe()
+	ch2, cancel2 := obs.Subscribe()
+	assert.Equal(t, <-ch1, <-ch2)
+	assert.Equal(t, <-ch1, <-ch2)
+	cancel1()
+	cancel2()
+	// unsubscribe is asynchronous
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, 1, subscriptions)
+
+	ch3, cancel3 := obs.Subscribe()
+	defer cancel3()
+	<-ch3
+	assert.Equal(t, 2, subscriptions)
+}
+
+func TestObservable_Share(t *testing.T) {
+	obs := rx_go.MapTo(rx_go.NewInterval(time.Millisecond*50, true), func(_ time.Time) int {
+		return 1
+	}).Pipe(rx_go.Take[int](3)).Share()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			defer wg.Done()
+			ch, _ := obs.Subscribe()
+			var res []int
+			for v := range ch {
+				res = append(res, v)
+			}
+			assert.NotEmpty(t, res)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestObservable_Share_Resubscribe(t *testing.T) {
+	var calls int32
+	obs := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(time.Millisecond * 50)
+		emitter.Next(1)
+		emitter.Next(2)
+	}).Share()
+
+	for i := 0; i < 2; i++ {
+		res, err := collectErr(obs)
+		assert.Equal(t, []int{1, 2}, res)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
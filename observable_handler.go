@@ -0,0 +1,182 @@
+package rx_go
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// handlerQueueSize - max amount of values of hot observable which wait for sending to single client of NewHttpHandler
+const handlerQueueSize = 1024
+
+// streamErrorMessage - message of the error sent to client by default, error text can contain internal urls and etc.
+const streamErrorMessage = "stream failed"
+
+// StreamEncoder - encode values of observable into streaming http response
+type StreamEncoder[T any] interface {
+	// ContentType - content type of the response
+	ContentType() string
+	// Encode - write single value
+	Encode(w io.Writer, value T) error
+	// Error - write error of the observable, response status is already sent
+	Error(w io.Writer, err error) error
+	// Heartbeat - write keep alive message
+	Heartbeat(w io.Writer) error
+}
+
+// SSEEncoder - encode values as Server-Sent Events
+type SSEEncoder[T any] struct {
+	// Event - type of events, field is not sent if it is empty
+	Event string
+	// Marshal - encode value into data of event, json by default
+	Marshal func(value T) ([]byte, error)
+	// ErrorMessage - return message of the error event, generic message is sent if it is nil(error text is not sent to client)
+	ErrorMessage func(err error) string
+}
+
+func (e SSEEncoder[T]) ContentType() string {
+	return "text/event-stream"
+}
+
+func (e SSEEncoder[T]) Encode(w io.Writer, value T) error {
+	marshal := e.Marshal
+	if marshal == nil {
+		marshal = func(value T) ([]byte, error) {
+			return json.Marshal(value)
+		}
+	}
+
+	data, err := marshal(value)
+	if err != nil {
+		return err
+	}
+	return writeSSE(w, e.Event, string(data))
+}
+
+func (e SSEEncoder[T]) Error(w io.Writer, err error) error {
+	return writeSSE(w, "error", errorMessage(e.ErrorMessage, err))
+}
+
+func (e SSEEncoder[T]) Heartbeat(w io.Writer) error {
+	_, err := io.WriteString(w, ": heartbeat\n\n")
+	return err
+}
+
+func writeSSE(w io.Writer, event string, data string) error {
+	var b strings.Builder
+	if event != "" {
+		b.WriteString("event: " + event + "\n")
+	}
+	for _, line := range strings.Split(data, "\n") {
+		b.WriteString("data: " + line + "\n")
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// NDJSONEncoder - encode values as json lines, error is sent as {"error": "message"}.
+// Heartbeat is sent as empty line, NDJSON parsers may ignore empty lines(json.Decoder and NewHttpNDJSON skip them)
+type NDJSONEncoder[T any] struct {
+	// ErrorMessage - return message of the error, generic message is sent if it is nil(error text is not sent to client)
+	ErrorMessage func(err error) string
+}
+
+func (e NDJSONEncoder[T]) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (e NDJSONEncoder[T]) Encode(w io.Writer, value T) error {
+	return json.NewEncoder(w).Encode(value)
+}
+
+func (e NDJSONEncoder[T]) Error(w io.Writer, err error) error {
+	return json.NewEncoder(w).Encode(map[string]string{
+		"error": errorMessage(e.ErrorMessage, err),
+	})
+}
+
+func (e NDJSONEncoder[T]) Heartbeat(w io.Writer) error {
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// errorMessage - return message of the error sent to client, generic message if fn is nil
+func errorMessage(fn func(err error) string, err error) string {
+	if fn == nil {
+		return streamErrorMessage
+	}
+	return fn(err)
+}
+
+// NewHttpHandler - return http.Handler which subscribe to the observable with context of each request and stream values with encoder.
+// Response is flushed after each value, heartbeat is sent with provided interval(zero disable it), subscription is cancelled after client disconnect.
+// Cold observable(Defer, Create, Share, subjects and etc.) is subscribed for each request. Hot observable is connected on first request and
+// shared between clients for the handler lifetime, client which does not read 1024 values in time is disconnected with ErrSlowSubscriber
+func NewHttpHandler[T any](o *Observable[T], encoder StreamEncoder[T], heartbeat time.Duration) http.Handler {
+	if o.factory == nil {
+		c := o.Publish()
+		var connect sync.Once
+		o = &Observable[T]{
+			factory: func(ctx context.Context) *Observer[T] {
+				observer := c.current(false).subscribeLimit(handlerQueueSize)
+				connect.Do(func() {
+					c.Connect()
+				})
+				return observer
+			},
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+
+		ch, source, cancel := o.subscribe(r.Context())
+		defer cancel()
+
+		w.Header().Set("Content-Type", encoder.ContentType())
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		var heartbeatCh <-chan time.Time
+		if heartbeat > 0 {
+			ticker := time.NewTicker(heartbeat)
+			defer ticker.Stop()
+			heartbeatCh = ticker.C
+		}
+
+		for {
+			select {
+			case value, ok := <-ch:
+				if !ok {
+					if err := source.Err(); err != nil {
+						_ = encoder.Error(w, err)
+						flusher.Flush()
+					}
+					return
+				}
+				if err := encoder.Encode(w, value); err != nil {
+					_ = encoder.Error(w, fmt.Errorf("rx_go: encode value: %w", err))
+					flusher.Flush()
+					return
+				}
+				flusher.Flush()
+			case <-heartbeatCh:
+				if err := encoder.Heartbeat(w); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	})
+}
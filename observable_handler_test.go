@@ -0,0 +1,141 @@
+package rx_go_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/PxyUp/rx_go"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewHttpHandler_SSE(t *testing.T) {
+	server := httptest.NewServer(rx_go.NewHttpHandler[jsonItem](
+		rx_go.Defer(func() *rx_go.Observable[jsonItem] {
+			return rx_go.From(jsonItem{ID: 1}, jsonItem{ID: 2})
+		}),
+		rx_go.SSEEncoder[jsonItem]{Event: "item"},
+		0,
+	))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "event: item\ndata: {\"id\":1}\n\nevent: item\ndata: {\"id\":2}\n\n", string(body))
+}
+
+func TestNewHttpHandler_NDJSON(t *testing.T) {
+	err := errors.New("failed")
+	server := httptest.NewServer(rx_go.NewHttpHandler[jsonItem](
+		rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[jsonItem]) {
+			emitter.Next(jsonItem{ID: 1})
+			emitter.Error(err)
+		}),
+		rx_go.NDJSONEncoder[jsonItem]{},
+		0,
+	))
+	defer server.Close()
+
+	resp, reqErr := server.Client().Get(server.URL)
+	assert.NoError(t, reqErr)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "{\"id\":1}\n{\"error\":\"stream failed\"}\n", string(body))
+}
+
+func TestNewHttpHandler_ErrorMessage(t *testing.T) {
+	obs := rx_go.Throw[int](errors.New("failed"))
+	server := httptest.NewServer(rx_go.NewHttpHandler[int](obs, rx_go.SSEEncoder[int]{
+		ErrorMessage: func(err error) string {
+			return err.Error()
+		},
+	}, 0))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "event: error\ndata: failed\n\n", string(body))
+}
+
+func TestNewHttpHandler_Disconnect(t *testing.T) {
+	stopped := make(chan struct{})
+	server := httptest.NewServer(rx_go.NewHttpHandler[int](
+		rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+			defer close(stopped)
+			emitter.Next(1)
+			<-ctx.Done()
+		}),
+		rx_go.NDJSONEncoder[int]{},
+		time.Millisecond*50,
+	))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	ch, cancel := rx_go.NewHttpLines(server.Client(), req).Subscribe()
+	assert.Equal(t, "1", <-ch)
+	// heartbeat
+	assert.Equal(t, "", <-ch)
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not cancelled")
+	}
+}
+
+func TestNewHttpHandler_Hot(t *testing.T) {
+	source := rx_go.NewObserver[int]()
+	server := httptest.NewServer(rx_go.NewHttpHandler[int](rx_go.New(source), rx_go.NDJSONEncoder[int]{}, 0))
+	defer server.Close()
+
+	client := server.Client()
+	client.Timeout = time.Second * 2
+	connect := func() (*http.Response, *bufio.Reader) {
+		resp, err := client.Get(server.URL)
+		assert.NoError(t, err)
+		return resp, bufio.NewReader(resp.Body)
+	}
+	resp1, reader1 := connect()
+	resp2, reader2 := connect()
+
+	source.Next(1)
+	for _, reader := range []*bufio.Reader{reader1, reader2} {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		assert.Equal(t, "1\n", line)
+	}
+
+	// first client disconnect does not complete observable for second one
+	resp1.Body.Close()
+	source.Next(2)
+	line, err := reader2.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "2\n", line)
+
+	// client connected after all previous clients disconnected receive next values
+	resp2.Body.Close()
+	// disconnect is handled by server asynchronously
+	time.Sleep(time.Millisecond * 100)
+	resp3, reader3 := connect()
+	defer resp3.Body.Close()
+	source.Next(3)
+	line, err = reader3.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "3\n", line)
+
+	source.Complete()
+	rest, err := io.ReadAll(reader3)
+	assert.NoError(t, err)
+	assert.Empty(t, rest)
+}
@@ -0,0 +1,323 @@
+package rx_go
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// maxErrorBodySize - max size of the body which is read into HttpStatusError
+const maxErrorBodySize = 64 * 1024
+
+// HttpResponse - response of http request with already read body
+type HttpResponse struct {
+	// Response - original response(status, headers and etc.), body is already read and closed
+	Response *http.Response
+	Body     []byte
+}
+
+// HttpStatusError - error of http observable if response status is not successful
+type HttpStatusError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	// Body - first 64KB of the response body
+	Body []byte
+}
+
+func (e *HttpStatusError) Error() string {
+	return fmt.Sprintf("rx_go: unexpected http status %s", e.Status)
+}
+
+// StatusChecker - return true if response status is successful
+type StatusChecker func(statusCode int) bool
+
+// Status2xx - successful only for 2xx statuses, default StatusChecker
+func Status2xx(statusCode int) bool {
+	return statusCode >= 200 && statusCode < 300
+}
+
+// AnyStatus - successful for all statuses
+func AnyStatus(_ int) bool {
+	return true
+}
+
+// NewHttpResponse - return lazy Observable which execute request on each subscription with context of the subscription, request is cancelled if its own context is done too.
+// Observable completes with HttpStatusError if checker(Status2xx by default) returns false
+func NewHttpResponse(client *http.Client, req *http.Request, checkers ...StatusChecker) *Observable[*HttpResponse] {
+	return createHttp(client, req, checkers, func(resp *http.Response, emitter Emitter[*HttpResponse]) error {
+		page, err := readHttpResponse(resp)
+		if err != nil {
+			return err
+		}
+		emitter.Next(page)
+		return nil
+	})
+}
+
+// NewHttpJSON - same as NewHttpResponse but decode json body into T, decode error completes observable with error.
+// Body can contain single value or many values(NDJSON), if T is not a slice(or interface) json array is emitted element by element.
+// Array is read into memory before its first element is emitted, use NewHttpNDJSON for long streams of values
+func NewHttpJSON[T any](client *http.Client, req *http.Request, checkers ...StatusChecker) *Observable[T] {
+	return createHttp(client, req, checkers, func(resp *http.Response, emitter Emitter[T]) error {
+		return decodeJSON(resp.Body, emitter)
+	})
+}
+
+// NewHttpStream - return lazy Observable which emit chunks of the response body as soon as they are read, body is closed after unsubscribe
+func NewHttpStream(client *http.Client, req *http.Request, checkers ...StatusChecker) *Observable[[]byte] {
+	return createHttp(client, req, checkers, func(resp *http.Response, emitter Emitter[[]byte]) error {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := resp.Body.Read(buf)
+			if n > 0 {
+				chunk := make([]byte, n)
+				copy(chunk, buf[:n])
+				emitter.Next(chunk)
+			}
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// NewHttpLines - return lazy Observable which emit lines(without line ending) of the response body as soon as they are read, body is closed after unsubscribe
+func NewHttpLines(client *http.Client, req *http.Request, checkers ...StatusChecker) *Observable[string] {
+	return createHttp(client, req, checkers, func(resp *http.Response, emitter Emitter[string]) error {
+		return readLines(resp.Body, func(line string) {
+			emitter.Next(line)
+		})
+	})
+}
+
+// NewHttpNDJSON - return lazy Observable which decode each line of the response body into T as soon as it is read, body is closed after unsubscribe.
+// Unlike NewHttpJSON json array is not split into elements, it is decoded into T as single value
+func NewHttpNDJSON[T any](client *http.Client, req *http.Request, checkers ...StatusChecker) *Observable[T] {
+	return createHttp(client, req, checkers, func(resp *http.Response, emitter Emitter[T]) error {
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var value T
+			err := decoder.Decode(&value)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			emitter.Next(value)
+		}
+	})
+}
+
+// createHttp - create lazy Observable which execute request on each subscription and pass successful response to handle, error of handle completes observable
+func createHttp[T any](client *http.Client, req *http.Request, checkers []StatusChecker, handle func(resp *http.Response, emitter Emitter[T]) error) *Observable[T] {
+	checker := statusChecker(checkers)
+	req = replayableRequest(req)
+	return Create(func(ctx context.Context, emitter Emitter[T]) {
+		resp, err := doHttp(ctx, client, req, checker)
+		if err != nil {
+			emitter.Error(err)
+			return
+		}
+		defer resp.Body.Close()
+
+		if err = handle(resp, emitter); err != nil {
+			emitter.Error(err)
+		}
+	})
+}
+
+// readHttpResponse - read body of the response, body is not closed
+func readHttpResponse(resp *http.Response) (*HttpResponse, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &HttpResponse{
+		Response: resp,
+		Body:     body,
+	}, nil
+}
+
+// readLines - call fn for each line of the reader without line ending
+func readLines(r io.Reader, fn func(line string)) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			fn(strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// decodeJSON - decode all json values from reader and emit them, json array is emitted element by element if T is not a slice or interface
+func decodeJSON[T any](r io.Reader, emitter Emitter[T]) error {
+	kind := reflect.TypeOf((*T)(nil)).Elem().Kind()
+	splitArray := kind != reflect.Slice && kind != reflect.Array && kind != reflect.Interface
+
+	decoder := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if splitArray && len(raw) > 0 && raw[0] == '[' {
+			var values []T
+			if err = json.Unmarshal(raw, &values); err != nil {
+				return err
+			}
+			for _, value := range values {
+				emitter.Next(value)
+			}
+			continue
+		}
+
+		var value T
+		if err = json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		emitter.Next(value)
+	}
+}
+
+// replayableRequest - return copy of the request which body is read into memory once on first execution if request has no GetBody,
+// so request can be executed on each subscription
+func replayableRequest(req *http.Request) *http.Request {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return req
+	}
+
+	var once sync.Once
+	var data []byte
+	var err error
+	body := req.Body
+
+	r := req.WithContext(req.Context())
+	r.GetBody = func() (io.ReadCloser, error) {
+		once.Do(func() {
+			defer body.Close()
+			data, err = io.ReadAll(body)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return r
+}
+
+func statusChecker(checkers []StatusChecker) StatusChecker {
+	if len(checkers) >= 1 && checkers[0] != nil {
+		return checkers[0]
+	}
+	return Status2xx
+}
+
+// doHttp - execute copy of the request with provided context and check response status, body of the response should be closed by caller.
+// Request is cancelled if provided context or own context of the request is done
+func doHttp(ctx context.Context, client *http.Client, req *http.Request, checker StatusChecker) (*http.Response, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := mergeContext(ctx, req.Context())
+	r := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		r.Body = body
+	}
+
+	resp, err := client.Do(r)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// request of the response keeps own context of the request, so request of the next page can be created from it
+	if resp.Request != nil {
+		resp.Request = resp.Request.WithContext(req.Context())
+	}
+
+	if !checker(resp.StatusCode) {
+		defer cancel()
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return nil, &HttpStatusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Header:     resp.Header,
+			Body:       body,
+		}
+	}
+
+	resp.Body = &cancelBody{
+		ReadCloser: resp.Body,
+		cancel:     cancel,
+	}
+	return resp, nil
+}
+
+// cancelBody - body of the response which cancel context of the request after close
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// mergeContext - return context which is done when ctx or other is done, values are taken from ctx
+func mergeContext(ctx context.Context, other context.Context) (context.Context, context.CancelFunc) {
+	if other.Done() == nil {
+		return context.WithCancel(ctx)
+	}
+
+	cancelDeadline := context.CancelFunc(func() {})
+	deadline, hasDeadline := other.Deadline()
+	if hasDeadline {
+		ctx, cancelDeadline = context.WithDeadline(ctx, deadline)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-other.Done():
+			// expired deadline is already applied, so context fails with context.DeadlineExceeded
+			if !hasDeadline || other.Err() != context.DeadlineExceeded {
+				cancel()
+			}
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		cancel()
+		cancelDeadline()
+	}
+}
@@ -0,0 +1,93 @@
+package rx_go
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrNoRequests - error of NewHttpHedged called without requests
+var ErrNoRequests = errors.New("rx_go: no requests")
+
+// NewHttpHedged - return lazy Observable which execute first request and start next attempt if response is not received in delay or previous attempt failed.
+// Attempt i use reqs[i % len(reqs)], attempts is len(reqs) if less than 1. First successful response is emitted and other attempts are cancelled,
+// observable completes with error of the last attempt if all of them failed or with ErrNoRequests if reqs is empty
+func NewHttpHedged(client *http.Client, delay time.Duration, attempts int, reqs ...*http.Request) *Observable[*HttpResponse] {
+	if attempts < 1 {
+		attempts = len(reqs)
+	}
+	replayable := make([]*http.Request, len(reqs))
+	for i, req := range reqs {
+		replayable[i] = replayableRequest(req)
+	}
+
+	type result struct {
+		resp *HttpResponse
+		err  error
+	}
+
+	return Create(func(ctx context.Context, emitter Emitter[*HttpResponse]) {
+		if len(replayable) == 0 {
+			emitter.Error(ErrNoRequests)
+			return
+		}
+
+		results := make(chan result, attempts)
+		cancelFns := make([]func(), 0, attempts)
+		defer func() {
+			for _, cancel := range cancelFns {
+				cancel()
+			}
+		}()
+
+		start := func() {
+			ch, source, cancel := NewHttpResponse(client, replayable[len(cancelFns)%len(replayable)]).subscribe(ctx)
+			cancelFns = append(cancelFns, cancel)
+			go func() {
+				if resp, ok := <-ch; ok {
+					results <- result{resp: resp}
+					return
+				}
+				err := source.Err()
+				if err == nil {
+					err = ctx.Err()
+				}
+				results <- result{err: err}
+			}()
+		}
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		start()
+		pending := 1
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				if len(cancelFns) < attempts {
+					start()
+					pending++
+					timer.Reset(delay)
+				}
+			case res := <-results:
+				if res.err == nil {
+					emitter.Next(res.resp)
+					return
+				}
+				pending--
+				if len(cancelFns) < attempts {
+					start()
+					pending++
+					continue
+				}
+				if pending == 0 {
+					emitter.Error(res.err)
+					return
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package rx_go_test
+
+import (
+	"errors"
+	"github.com/PxyUp/rx_go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewHttpHedged(t *testing.T) {
+	var cancelled int32
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			atomic.AddInt32(&cancelled, 1)
+		case <-time.After(time.Second):
+			_, _ = w.Write([]byte("slow"))
+		}
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("fast"))
+	}))
+	defer fast.Close()
+
+	slowReq, _ := http.NewRequest(http.MethodGet, slow.URL, nil)
+	fastReq, _ := http.NewRequest(http.MethodGet, fast.URL, nil)
+
+	start := time.Now()
+	res, err := collectErr(rx_go.NewHttpHedged(http.DefaultClient, time.Millisecond*50, 0, slowReq, fastReq))
+	assert.Len(t, res, 1)
+	assert.Equal(t, "fast", string(res[0].Body))
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Millisecond*500)
+
+	time.Sleep(time.Millisecond * 100)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&cancelled))
+}
+
+func TestNewHttpHedged_Error(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := collectErr(rx_go.NewHttpHedged(http.DefaultClient, time.Second, 3, req))
+	var statusErr *rx_go.HttpStatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestNewHttpHedged_NoRequests(t *testing.T) {
+	res, err := collectErr(rx_go.NewHttpHedged(http.DefaultClient, time.Second, 3))
+	assert.Empty(t, res)
+	assert.ErrorIs(t, err, rx_go.ErrNoRequests)
+}
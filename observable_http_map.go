@@ -0,0 +1,97 @@
+package rx_go
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// HttpResult - result of the request created from value by HttpMap
+type HttpResult[T any] struct {
+	Value    T
+	Response *HttpResponse
+	Err      error
+}
+
+// HttpMap - return lazy Observable which execute request created by toRequest for each value, at most concurrency requests are executed in parallel.
+// Results are emitted in order of values if ordered is true, otherwise as soon as request is done. Request errors are emitted as part of HttpResult and do not complete observable
+func HttpMap[T any](o *Observable[T], client *http.Client, toRequest func(value T) (*http.Request, error), concurrency int, ordered bool, checkers ...StatusChecker) *Observable[HttpResult[T]] {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	checker := statusChecker(checkers)
+
+	return Create(func(ctx context.Context, emitter Emitter[HttpResult[T]]) {
+		ch, source, cancel := o.subscribe(ctx)
+		defer cancel()
+
+		run := func(value T) (result HttpResult[T]) {
+			result.Value = value
+			defer func() {
+				if r := recover(); r != nil {
+					result.Err = newPanicError(r)
+				}
+			}()
+
+			req, err := toRequest(value)
+			if err != nil {
+				result.Err = err
+				return
+			}
+			resp, err := doHttp(ctx, client, req, checker)
+			if err != nil {
+				result.Err = err
+				return
+			}
+			defer resp.Body.Close()
+			result.Response, result.Err = readHttpResponse(resp)
+			return
+		}
+
+		// sem limit amount of requests which are executed or wait for emitting in order
+		sem := make(chan struct{}, concurrency)
+		queue := make(chan chan HttpResult[T], concurrency)
+		queueDone := make(chan struct{})
+		go func() {
+			defer close(queueDone)
+			for slot := range queue {
+				emitter.Next(<-slot)
+				<-sem
+			}
+		}()
+
+		var wg sync.WaitGroup
+		for value := range ch {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				continue
+			}
+
+			slot := make(chan HttpResult[T], 1)
+			if ordered {
+				queue <- slot
+			}
+
+			wg.Add(1)
+			go func(value T) {
+				defer wg.Done()
+				result := run(value)
+				if ordered {
+					slot <- result
+					return
+				}
+				emitter.Next(result)
+				<-sem
+			}(value)
+		}
+
+		wg.Wait()
+		close(queue)
+		<-queueDone
+
+		if err := source.Err(); err != nil {
+			emitter.Error(err)
+		}
+	})
+}
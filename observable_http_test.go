@@ -0,0 +1,298 @@
+package rx_go_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PxyUp/rx_go"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewHttpResponse(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Test", "test")
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("hello"))
+	obs := rx_go.NewHttpResponse(server.Client(), req)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+
+	for i := 0; i < 2; i++ {
+		ch, _ := obs.Subscribe()
+		resp := <-ch
+		assert.Equal(t, http.StatusOK, resp.Response.StatusCode)
+		assert.Equal(t, "test", resp.Response.Header.Get("X-Test"))
+		assert.Equal(t, []byte("hello"), resp.Body)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestNewHttpResponse_Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed"))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := collectErr(rx_go.NewHttpResponse(server.Client(), req))
+	var statusErr *rx_go.HttpStatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+	assert.Equal(t, []byte("failed"), statusErr.Body)
+
+	res, err := collectErr(rx_go.NewHttpResponse(server.Client(), req, rx_go.AnyStatus))
+	assert.Len(t, res, 1)
+	assert.Equal(t, http.StatusInternalServerError, res[0].Response.StatusCode)
+	assert.NoError(t, err)
+}
+
+func TestNewHttpResponse_Body(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer server.Close()
+
+	// reader without GetBody
+	body := struct{ io.Reader }{strings.NewReader("hello")}
+	req, _ := http.NewRequest(http.MethodPost, server.URL, body)
+	obs := rx_go.NewHttpResponse(server.Client(), req)
+	for i := 0; i < 2; i++ {
+		res, err := collectErr(obs)
+		assert.Len(t, res, 1)
+		assert.Equal(t, "hello", string(res[0].Body))
+		assert.NoError(t, err)
+	}
+}
+
+func TestNewHttpResponse_StatusBodyLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(strings.Repeat("a", 100*1024)))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err := collectErr(rx_go.NewHttpResponse(server.Client(), req))
+	var statusErr *rx_go.HttpStatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Len(t, statusErr.Body, 64*1024)
+}
+
+func TestNewHttpResponse_Context(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second * 5):
+		}
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	sub := rx_go.NewHttpResponse(server.Client(), req).SubscribeWith(ctx, rx_go.Handlers[*rx_go.HttpResponse]{})
+	select {
+	case <-sub.Done():
+	case <-time.After(time.Second):
+		t.Fatal("request was not cancelled")
+	}
+	assert.Error(t, sub.Err())
+}
+
+func TestNewHttpResponse_RequestContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Millisecond * 500):
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	start := time.Now()
+	_, err := collectErr(rx_go.NewHttpResponse(server.Client(), req))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, time.Since(start) < time.Millisecond*400)
+}
+
+type jsonItem struct {
+	ID int `json:"id"`
+}
+
+func jsonServer(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestNewHttpJSON(t *testing.T) {
+	server := jsonServer(`{"id": 1}`)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	ch, _ := rx_go.NewHttpJSON[jsonItem](server.Client(), req).Subscribe()
+	var res []jsonItem
+	for v := range ch {
+		res = append(res, v)
+	}
+	assert.Equal(t, []jsonItem{{ID: 1}}, res)
+}
+
+func TestNewHttpJSON_NDJSON(t *testing.T) {
+	server := jsonServer("{\"id\": 1}\n{\"id\": 2}\n{\"id\": 3}\n")
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	ch, _ := rx_go.NewHttpJSON[jsonItem](server.Client(), req).Subscribe()
+	var res []jsonItem
+	for v := range ch {
+		res = append(res, v)
+	}
+	assert.Equal(t, []jsonItem{{ID: 1}, {ID: 2}, {ID: 3}}, res)
+}
+
+func TestNewHttpJSON_Array(t *testing.T) {
+	server := jsonServer(`[{"id": 1}, {"id": 2}]`)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	ch, _ := rx_go.NewHttpJSON[jsonItem](server.Client(), req).Subscribe()
+	var res []jsonItem
+	for v := range ch {
+		res = append(res, v)
+	}
+	assert.Equal(t, []jsonItem{{ID: 1}, {ID: 2}}, res)
+
+	chSlice, _ := rx_go.NewHttpJSON[[]jsonItem](server.Client(), req).Subscribe()
+	assert.Equal(t, []jsonItem{{ID: 1}, {ID: 2}}, <-chSlice)
+}
+
+func TestNewHttpJSON_Error(t *testing.T) {
+	server := jsonServer(`{"id": "1"}`)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	obs := rx_go.NewHttpJSON[jsonItem](server.Client(), req)
+	ch, _ := obs.Subscribe()
+	for range ch {
+	}
+	var typeErr *json.UnmarshalTypeError
+	assert.True(t, errors.As(obs.Err(), &typeErr))
+}
+
+// streamServer write lines with flush and wait for client disconnect, closed is closed after disconnect
+func streamServer(lines []string) (*httptest.Server, chan struct{}) {
+	closed := make(chan struct{})
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(closed)
+		for _, line := range lines {
+			_, _ = w.Write([]byte(line))
+			w.(http.Flusher).Flush()
+		}
+		<-r.Context().Done()
+	})), closed
+}
+
+func TestNewHttpStream(t *testing.T) {
+	server, closed := streamServer([]string{"hello"})
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	ch, cancel := rx_go.NewHttpStream(server.Client(), req).Subscribe()
+	assert.Equal(t, []byte("hello"), <-ch)
+	cancel()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("body was not closed")
+	}
+}
+
+func TestNewHttpLines(t *testing.T) {
+	server, closed := streamServer([]string{"first\r\n", "sec", "ond\n"})
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	ch, cancel := rx_go.NewHttpLines(server.Client(), req).Subscribe()
+	assert.Equal(t, "first", <-ch)
+	assert.Equal(t, "second", <-ch)
+	cancel()
+	<-closed
+}
+
+func TestNewHttpNDJSON(t *testing.T) {
+	server, closed := streamServer([]string{"{\"id\": 1}\n", "{\"id\": 2}\n"})
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	ch, cancel := rx_go.NewHttpNDJSON[jsonItem](server.Client(), req).Subscribe()
+	assert.Equal(t, jsonItem{ID: 1}, <-ch)
+	assert.Equal(t, jsonItem{ID: 2}, <-ch)
+	cancel()
+	<-closed
+}
+
+func TestHttpMap(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			prev := atomic.LoadInt32(&maxInFlight)
+			if current <= prev || atomic.CompareAndSwapInt32(&maxInFlight, prev, current) {
+				break
+			}
+		}
+		id := r.URL.Query().Get("id")
+		// first requests are slower
+		if id == "1" || id == "2" {
+			time.Sleep(time.Millisecond * 100)
+		}
+		if id == "4" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(id))
+	}))
+	defer server.Close()
+
+	toRequest := func(id int) (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, fmt.Sprintf("%s?id=%d", server.URL, id), nil)
+	}
+
+	ch, _ := rx_go.HttpMap(rx_go.From(1, 2, 3, 4, 5), server.Client(), toRequest, 2, true).Subscribe()
+	var res []string
+	for result := range ch {
+		if result.Err != nil {
+			res = append(res, fmt.Sprintf("%d error", result.Value))
+			continue
+		}
+		res = append(res, string(result.Response.Body))
+	}
+	assert.Equal(t, []string{"1", "2", "3", "4 error", "5"}, res)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+
+	ch, _ = rx_go.HttpMap(rx_go.From(1, 2, 3), server.Client(), toRequest, 3, false).Subscribe()
+	res = nil
+	for result := range ch {
+		res = append(res, string(result.Response.Body))
+	}
+	assert.Equal(t, "3", res[0])
+	assert.ElementsMatch(t, []string{"1", "2", "3"}, res)
+}
@@ -0,0 +1,128 @@
+package rx_go
+
+import (
+	"context"
+	"sync"
+)
+
+// innerSubscriptions - active subscriptions to inner observables of SwitchMap, ConcatMap, MergeMap and ExhaustMap, values of inner observables are emitted to observer
+type innerSubscriptions[Y any] struct {
+	observer *Observer[Y]
+
+	mutex     sync.Mutex
+	cancelFns map[int]context.CancelFunc
+	nextID    int
+	stopped   bool
+	wg        sync.WaitGroup
+
+	// emitMutex - values are emitted under read lock, cancelAll take write lock so value of cancelled observable can not be emitted after it returned
+	emitMutex sync.RWMutex
+}
+
+func newInnerSubscriptions[Y any](observer *Observer[Y]) *innerSubscriptions[Y] {
+	return &innerSubscriptions[Y]{
+		observer:  observer,
+		cancelFns: make(map[int]context.CancelFunc),
+	}
+}
+
+// start - subscribe to observable and emit its values in background, error of observable completes observer.
+// done(if not nil) is called after observable completed or unsubscribed
+func (s *innerSubscriptions[Y]) start(o *Observable[Y], done func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stopped {
+		if done != nil {
+			done()
+		}
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	id := s.nextID
+	s.nextID++
+	s.cancelFns[id] = cancel
+
+	ch, source, _ := o.subscribe(ctx)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if done != nil {
+			defer done()
+		}
+		defer s.remove(id)
+
+		for value := range ch {
+			if !s.emit(ctx, func() {
+				s.observer.Next(value)
+			}) {
+				return
+			}
+		}
+		if err := source.Err(); err != nil {
+			s.emit(ctx, func() {
+				s.observer.Error(err)
+			})
+		}
+	}()
+}
+
+// emit - call fn if observable with ctx is not cancelled, return false if it is cancelled.
+// Value of cancelled observable should not be emitted after switching to next one
+func (s *innerSubscriptions[Y]) emit(ctx context.Context, fn func()) bool {
+	s.emitMutex.RLock()
+	defer s.emitMutex.RUnlock()
+	if ctx.Err() != nil {
+		return false
+	}
+	fn()
+	return true
+}
+
+// remove - unsubscribe from observable with id
+func (s *innerSubscriptions[Y]) remove(id int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if cancel, ok := s.cancelFns[id]; ok {
+		cancel()
+		delete(s.cancelFns, id)
+	}
+}
+
+// cancelAll - unsubscribe from all active observables, wait for values which are being emitted
+func (s *innerSubscriptions[Y]) cancelAll() {
+	s.emitMutex.Lock()
+	defer s.emitMutex.Unlock()
+	s.cancel()
+}
+
+// cancel - unsubscribe from all active observables without waiting for emitting values
+func (s *innerSubscriptions[Y]) cancel() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for id, cancel := range s.cancelFns {
+		cancel()
+		delete(s.cancelFns, id)
+	}
+}
+
+// stop - unsubscribe from all active observables, next observables will not be subscribed.
+// It is called after observer completed, so emitting values are ignored and are not waited(observer can be locked by its completion)
+func (s *innerSubscriptions[Y]) stop() {
+	s.mutex.Lock()
+	s.stopped = true
+	s.mutex.Unlock()
+	s.cancel()
+}
+
+// active - return amount of active subscriptions
+func (s *innerSubscriptions[Y]) active() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.cancelFns)
+}
+
+// wait - wait until all active observables are completed or unsubscribed
+func (s *innerSubscriptions[Y]) wait() {
+	s.wg.Wait()
+}
@@ -2,16 +2,33 @@ package rx_go
 
 import (
 	"context"
+	"sync"
 )
 
 type Observable[T any] struct {
 	observer *Observer[T]
+	// factory create new observer for each subscription(cold observable), observer is ignored if it is set
+	factory func(ctx context.Context) *Observer[T]
+
+	// last - observer of the last subscription created by Subscribe, SubscribeErr or SubscribeWith, used by Err of cold observable
+	last      *Observer[T]
+	lastMutex sync.RWMutex
 }
 
 type Operator[T any] func(observer *Observer[T]) *Observer[T]
 
 func (o *Observable[T]) Pipe(operators ...Operator[T]) *Observable[T] {
-	old := o.observer
+	if o.factory != nil {
+		return &Observable[T]{
+			factory: func(ctx context.Context) *Observer[T] {
+				return pipe(o.factory(ctx), operators...)
+			},
+		}
+	}
+	return New(pipe(o.observer, operators...))
+}
+
+func pipe[T any](old *Observer[T], operators ...Operator[T]) *Observer[T] {
 	for _, op := range operators {
 
 		copyOldOnCompleteFn := old.onComplete
@@ -34,26 +51,114 @@ func (o *Observable[T]) Pipe(operators ...Operator[T]) *Observable[T] {
 
 		old = newObs
 	}
-	return New(old)
+	return old
 }
 
-// Subscribe - create channel for reading values and unsubscribe function
+// Err - return error which terminated the observable, nil if observable completed without error or still active.
+// Cold observable has own error for each subscription, error of the last subscription created by Subscribe, SubscribeErr or SubscribeWith is returned.
+// Use SubscribeErr or SubscribeWith if cold observable is subscribed many times concurrently
+func (o *Observable[T]) Err() error {
+	if o.factory == nil {
+		return o.observer.Err()
+	}
+
+	o.lastMutex.RLock()
+	defer o.lastMutex.RUnlock()
+	if o.last == nil {
+		return nil
+	}
+	return o.last.Err()
+}
+
+// Subscribe - create channel for reading values and unsubscribe function, channel is closed after observable completed with or without error.
+// Use SubscribeErr or SubscribeWith for reading error of the subscription
 func (o *Observable[T]) Subscribe(ctxs ...context.Context) (chan T, func()) {
+	t, _, cancel := o.SubscribeErr(ctxs...)
+	return t, cancel
+}
+
+// SubscribeErr - same as Subscribe but also return function which return error of the subscription,
+// nil if observable completed without error or channel is not closed yet
+func (o *Observable[T]) SubscribeErr(ctxs ...context.Context) (chan T, func() error, func()) {
 	lCtx := context.Background()
 	if len(ctxs) >= 1 {
 		lCtx = ctxs[0]
 	}
 
+	t, source, cancel := o.subscribe(lCtx)
+	o.track(source)
+	return t, source.Err, cancel
+}
+
+// track - remember observer of the subscription for Err
+func (o *Observable[T]) track(source *Observer[T]) {
+	if o.factory == nil {
+		return
+	}
+	o.lastMutex.Lock()
+	defer o.lastMutex.Unlock()
+	o.last = source
+}
+
+// source - return observer for new subscription
+func (o *Observable[T]) source(ctx context.Context) *Observer[T] {
+	if o.factory == nil {
+		return o.observer
+	}
+
+	return o.factory(ctx)
+}
+
+// cold - return true if observable create new observer for each subscription
+func (o *Observable[T]) cold() bool {
+	return o.factory != nil
+}
+
+// anyCold - return true if at least one of observables is cold
+func anyCold[T any](obss []*Observable[T]) bool {
+	for _, o := range obss {
+		if o.cold() {
+			return true
+		}
+	}
+	return false
+}
+
+// lazy - return cold observable which call subscribe for each subscription with its context if cold is true,
+// otherwise subscribe is called immediately and observable is hot
+func lazy[T any](cold bool, subscribe func(ctx context.Context) *Observer[T]) *Observable[T] {
+	if cold {
+		return &Observable[T]{
+			factory: subscribe,
+		}
+	}
+	return New(subscribe(context.Background()))
+}
+
+// discard - complete hot observable which will not be subscribed, so its producer is not blocked forever.
+// Cold observable is not started before subscription and nothing should be done
+func (o *Observable[T]) discard() {
+	if o.factory == nil {
+		o.observer.Complete()
+	}
+}
+
+// subscribe - same as Subscribe but also return observer of the subscription, it should be used for reading error of the subscription
+func (o *Observable[T]) subscribe(lCtx context.Context) (chan T, *Observer[T], func()) {
 	t := make(chan T)
 	ctx, cancel := context.WithCancel(lCtx)
+	observer := o.source(ctx)
 	go func() {
-		<-ctx.Done()
-		o.observer.Complete()
+		select {
+		case <-ctx.Done():
+			observer.Complete()
+		case <-observer.done:
+		}
 	}()
 
 	go func() {
-		if o.observer.onSubscribe != nil {
-			o.observer.onSubscribe()
+		if observer.onSubscribe != nil {
+			observer.onSubscribe()
 		}
 
 		defer close(t)
@@ -61,13 +166,17 @@ func (o *Observable[T]) Subscribe(ctxs ...context.Context) (chan T, func()) {
 			select {
 			case <-ctx.Done():
 				return
-			case value, ok := <-o.observer.list:
+			case value, ok := <-observer.list:
 				if !ok {
 					return
 				}
-				t <- value
+				select {
+				case t <- value:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}()
-	return t, cancel
+	return t, observer, cancel
 }
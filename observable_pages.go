@@ -0,0 +1,159 @@
+package rx_go
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// NextPage - return request of the next page or nil if page is the last one
+type NextPage func(page *HttpResponse) (*http.Request, error)
+
+// LinkNextPage - NextPage which follow url from Link header with rel="next"
+func LinkNextPage(page *HttpResponse) (*http.Request, error) {
+	for _, header := range page.Response.Header.Values("Link") {
+		for _, link := range splitLinks(header) {
+			link = strings.TrimSpace(link)
+			end := strings.Index(link, ">")
+			if !strings.HasPrefix(link, "<") || end < 0 {
+				continue
+			}
+			target := link[1:end]
+
+			for _, param := range strings.Split(link[end+1:], ";") {
+				key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(key, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return nextPageRequest(page, target)
+					}
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// splitLinks - split Link header into link-values, commas inside <url> and quoted params do not separate values
+func splitLinks(header string) []string {
+	var links []string
+	inURL, inQuotes := false, false
+	start := 0
+	for i, r := range header {
+		switch {
+		case inQuotes:
+			if r == '"' {
+				inQuotes = false
+			}
+		case inURL:
+			if r == '>' {
+				inURL = false
+			}
+		case r == '"':
+			inQuotes = true
+		case r == '<':
+			inURL = true
+		case r == ',':
+			links = append(links, header[start:i])
+			start = i + 1
+		}
+	}
+	return append(links, header[start:])
+}
+
+// sensitiveHeaders - headers which are removed from request of the next page on other host or with insecure scheme
+var sensitiveHeaders = []string{"Authorization", "Www-Authenticate", "Cookie", "Cookie2"}
+
+// nextPageRequest - copy request of the page with new url resolved relatively to the page url
+func nextPageRequest(page *HttpResponse, rawURL string) (*http.Request, error) {
+	prev := page.Response.Request
+	u, err := prev.URL.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req := prev.Clone(prev.Context())
+	req.URL = u
+	req.Host = u.Host
+	// same as net/http redirects credentials are not sent to other host, also they are not sent in clear text after https
+	if u.Host != prev.URL.Host || (prev.URL.Scheme == "https" && u.Scheme != "https") {
+		for _, header := range sensitiveHeaders {
+			req.Header.Del(header)
+		}
+	}
+	return req, nil
+}
+
+// NewHttpPages - return lazy Observable which emit pages starting from req, request of the next page is returned by next(LinkNextPage if nil).
+// Next page is not requested after unsubscribe
+func NewHttpPages(client *http.Client, req *http.Request, next NextPage, checkers ...StatusChecker) *Observable[*HttpResponse] {
+	if next == nil {
+		next = LinkNextPage
+	}
+	checker := statusChecker(checkers)
+	req = replayableRequest(req)
+
+	return Create(func(ctx context.Context, emitter Emitter[*HttpResponse]) {
+		r := req
+		for r != nil {
+			resp, err := doHttp(ctx, client, r, checker)
+			if err != nil {
+				emitter.Error(err)
+				return
+			}
+
+			page, err := readHttpResponse(resp)
+			resp.Body.Close()
+			if err != nil {
+				emitter.Error(err)
+				return
+			}
+
+			emitter.Next(page)
+			if ctx.Err() != nil {
+				return
+			}
+
+			r, err = next(page)
+			if err != nil {
+				emitter.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// NewHttpPageItems - same as NewHttpPages but emit items of each page returned by items(json array body decoded into []T if nil)
+func NewHttpPageItems[T any](client *http.Client, req *http.Request, next NextPage, items func(page *HttpResponse) ([]T, error), checkers ...StatusChecker) *Observable[T] {
+	if items == nil {
+		items = func(page *HttpResponse) ([]T, error) {
+			var values []T
+			err := json.Unmarshal(page.Body, &values)
+			return values, err
+		}
+	}
+
+	pages := NewHttpPages(client, req, next, checkers...)
+	return Create(func(ctx context.Context, emitter Emitter[T]) {
+		ch, source, cancel := pages.subscribe(ctx)
+		defer cancel()
+
+		for page := range ch {
+			values, err := items(page)
+			if err != nil {
+				emitter.Error(err)
+				return
+			}
+			for _, value := range values {
+				emitter.Next(value)
+			}
+		}
+
+		if err := source.Err(); err != nil {
+			emitter.Error(err)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package rx_go_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/PxyUp/rx_go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func pagesServer(calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 2 {
+			w.Header().Set("Link", fmt.Sprintf(`</items?page=%d>; rel="next", </items?page=2>; rel="last"`, page+1))
+		}
+		_, _ = fmt.Fprintf(w, `[{"id": %d}, {"id": %d}]`, page*2, page*2+1)
+	}))
+}
+
+func TestNewHttpPages(t *testing.T) {
+	var calls int32
+	server := pagesServer(&calls)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/items", nil)
+	ch, _ := rx_go.NewHttpPages(server.Client(), req, nil).Subscribe()
+	var res []string
+	for page := range ch {
+		res = append(res, page.Response.Request.URL.RawQuery+" "+string(page.Body))
+	}
+	assert.Equal(t, []string{
+		` [{"id": 0}, {"id": 1}]`,
+		`page=1 [{"id": 2}, {"id": 3}]`,
+		`page=2 [{"id": 4}, {"id": 5}]`,
+	}, res)
+}
+
+func TestNewHttpPages_OtherHost(t *testing.T) {
+	headers := make(chan http.Header, 2)
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", `</items?page=1>; rel="next"`)
+			return
+		}
+		headers <- r.Header.Clone()
+		w.Header().Set("Link", fmt.Sprintf(`<%s/items>; rel="next"`, other.URL))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/items", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("X-Request", "value")
+	ch, _ := rx_go.NewHttpPages(http.DefaultClient, req, nil).Subscribe()
+	assert.Len(t, collect(ch), 3)
+
+	sameHost := <-headers
+	assert.Equal(t, "Bearer secret", sameHost.Get("Authorization"))
+	assert.Equal(t, "session=secret", sameHost.Get("Cookie"))
+
+	otherHost := <-headers
+	assert.Empty(t, otherHost.Get("Authorization"))
+	assert.Empty(t, otherHost.Get("Cookie"))
+	assert.Equal(t, "value", otherHost.Get("X-Request"))
+}
+
+func TestLinkNextPage_Comma(t *testing.T) {
+	prev, _ := http.NewRequest(http.MethodGet, "https://example.com/items", nil)
+	page := &rx_go.HttpResponse{
+		Response: &http.Response{
+			Request: prev,
+			Header: http.Header{
+				"Link": []string{`<https://example.com/items?ids=1,2>; rel="prev last", <https://example.com/items?ids=3,4>; title="a, b"; rel="next"`},
+			},
+		},
+	}
+
+	req, err := rx_go.LinkNextPage(page)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/items?ids=3,4", req.URL.String())
+}
+
+func TestNewHttpPages_Comma(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ids") == "" {
+			w.Header().Set("Link", `</items?ids=1,2>; rel="next"`)
+		}
+		_, _ = fmt.Fprint(w, r.URL.Query().Get("ids"))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/items", nil)
+	res, err := collectErr(rx_go.NewHttpPages(server.Client(), req, nil))
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+	assert.Equal(t, "1,2", string(res[1].Body))
+}
+
+func TestLinkNextPage_SchemeDowngrade(t *testing.T) {
+	prev, _ := http.NewRequest(http.MethodGet, "https://example.com/items", nil)
+	prev.Header.Set("Authorization", "Bearer secret")
+	prev.Header.Set("Cookie", "session=secret")
+	page := &rx_go.HttpResponse{
+		Response: &http.Response{
+			Request: prev,
+			Header: http.Header{
+				"Link": []string{`<http://example.com/items?page=1>; rel="next"`},
+			},
+		},
+	}
+
+	req, err := rx_go.LinkNextPage(page)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://example.com/items?page=1", req.URL.String())
+	assert.Empty(t, req.Header.Get("Authorization"))
+	assert.Empty(t, req.Header.Get("Cookie"))
+
+	page.Response.Header.Set("Link", `</items?page=1>; rel="next"`)
+	req, err = rx_go.LinkNextPage(page)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/items?page=1", req.URL.String())
+	assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+}
+
+func TestNewHttpPages_Unsubscribe(t *testing.T) {
+	var calls int32
+	server := pagesServer(&calls)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/items", nil)
+	ch, _ := rx_go.NewHttpPages(server.Client(), req, nil).Pipe(rx_go.Take[*rx_go.HttpResponse](1)).Subscribe()
+	for range ch {
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestNewHttpPageItems(t *testing.T) {
+	var calls int32
+	server := pagesServer(&calls)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/items", nil)
+	ch, _ := rx_go.NewHttpPageItems[jsonItem](server.Client(), req, nil, nil).Subscribe()
+	var res []int
+	for item := range ch {
+		res = append(res, item.ID)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, res)
+}
+
+func TestNewHttpPageItems_Cursor(t *testing.T) {
+	type page struct {
+		Items  []jsonItem `json:"items"`
+		Cursor string     `json:"cursor"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"items": [{"id": 1}], "cursor": "next"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": [{"id": 2}]}`))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	ch, _ := rx_go.NewHttpPageItems(server.Client(), req, func(resp *rx_go.HttpResponse) (*http.Request, error) {
+		var p page
+		if err := json.Unmarshal(resp.Body, &p); err != nil || p.Cursor == "" {
+			return nil, err
+		}
+		return http.NewRequest(http.MethodGet, server.URL+"?cursor="+p.Cursor, nil)
+	}, func(resp *rx_go.HttpResponse) ([]jsonItem, error) {
+		var p page
+		err := json.Unmarshal(resp.Body, &p)
+		return p.Items, err
+	}).Subscribe()
+	var res []int
+	for item := range ch {
+		res = append(res, item.ID)
+	}
+	assert.Equal(t, []int{1, 2}, res)
+}
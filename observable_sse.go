@@ -0,0 +1,166 @@
+package rx_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSSERetry - reconnection delay of NewSSE if server did not send retry field
+const DefaultSSERetry = time.Second * 3
+
+// ErrNotEventStream - error of NewSSE if content type of the response is not text/event-stream
+var ErrNotEventStream = errors.New("rx_go: response is not event stream")
+
+// SSEEvent - event of Server-Sent Events stream
+type SSEEvent struct {
+	// ID - last event id of the stream
+	ID string
+	// Event - type of the event, "message" by default
+	Event string
+	// Data - data lines of the event joined with "\n"
+	Data string
+	// Retry - reconnection delay sent with the event, zero if it was not sent
+	Retry time.Duration
+}
+
+// NewSSE - return lazy Observable of Server-Sent Events, after disconnect request is executed again with Last-Event-ID header
+// after retry delay(DefaultSSERetry if zero) or delay sent by server. Not 2xx status completes observable with HttpStatusError, 204 status completes it without error.
+// Response which is not text/event-stream completes observable with ErrNotEventStream, only network errors are retried and other errors complete observable
+func NewSSE(client *http.Client, req *http.Request, retry time.Duration) *Observable[SSEEvent] {
+	if retry <= 0 {
+		retry = DefaultSSERetry
+	}
+	req = replayableRequest(req)
+
+	return Create(func(ctx context.Context, emitter Emitter[SSEEvent]) {
+		parser := &sseParser{
+			retry: retry,
+		}
+
+		for {
+			r := req.Clone(req.Context())
+			r.Header.Set("Accept", "text/event-stream")
+			r.Header.Set("Cache-Control", "no-cache")
+			if parser.lastEventID != "" {
+				r.Header.Set("Last-Event-ID", parser.lastEventID)
+			}
+
+			resp, err := doHttp(ctx, client, r, Status2xx)
+			if err == nil {
+				if resp.StatusCode == http.StatusNoContent {
+					resp.Body.Close()
+					return
+				}
+				if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/event-stream" {
+					resp.Body.Close()
+					emitter.Error(fmt.Errorf("%w: %q", ErrNotEventStream, resp.Header.Get("Content-Type")))
+					return
+				}
+				err = readLines(resp.Body, func(line string) {
+					if event, ok := parser.line(line); ok {
+						emitter.Next(event)
+					}
+				})
+				resp.Body.Close()
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			// own context of the request is done, reconnection will fail too
+			if err != nil && (!sseRetryable(err) || req.Context().Err() != nil) {
+				emitter.Error(err)
+				return
+			}
+
+			parser.reset()
+			timer := time.NewTimer(parser.retry)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	})
+}
+
+// sseRetryable - return true if connection can be established again after error, network errors and unexpected end of the stream are retried
+func sseRetryable(err error) bool {
+	// url.Error implements net.Error itself, so error of the transport is checked
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// sseParser - parse lines of event stream, last event id and retry are kept between connections
+type sseParser struct {
+	lastEventID string
+	retry       time.Duration
+
+	event      string
+	data       []string
+	eventRetry time.Duration
+}
+
+// line - process single line of the stream, return event if line finish it
+func (p *sseParser) line(line string) (SSEEvent, bool) {
+	if line == "" {
+		defer p.reset()
+		if p.data == nil {
+			return SSEEvent{}, false
+		}
+		event := p.event
+		if event == "" {
+			event = "message"
+		}
+		return SSEEvent{
+			ID:    p.lastEventID,
+			Event: event,
+			Data:  strings.Join(p.data, "\n"),
+			Retry: p.eventRetry,
+		}, true
+	}
+
+	if strings.HasPrefix(line, ":") {
+		return SSEEvent{}, false
+	}
+
+	field, value, _ := strings.Cut(line, ":")
+	value = strings.TrimPrefix(value, " ")
+
+	switch field {
+	case "event":
+		p.event = value
+	case "data":
+		p.data = append(p.data, value)
+	case "id":
+		if !strings.Contains(value, "\x00") {
+			p.lastEventID = value
+		}
+	case "retry":
+		if ms, err := strconv.ParseUint(value, 10, 64); err == nil {
+			p.retry = time.Duration(ms) * time.Millisecond
+			p.eventRetry = p.retry
+		}
+	}
+	return SSEEvent{}, false
+}
+
+// reset - drop not finished event
+func (p *sseParser) reset() {
+	p.event = ""
+	p.data = nil
+	p.eventRetry = 0
+}
@@ -0,0 +1,101 @@
+package rx_go_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/PxyUp/rx_go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewSSE(t *testing.T) {
+	var mutex sync.Mutex
+	var lastEventIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		connection := len(lastEventIDs)
+		mutex.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if connection == 1 {
+			_, _ = fmt.Fprint(w, ": comment\nretry: 50\nid: 1\nevent: update\ndata: first\ndata: line\n\n")
+			_, _ = fmt.Fprint(w, "id: 2\ndata:second\n\ndata: not finished")
+			return
+		}
+		_, _ = fmt.Fprint(w, "id: 3\ndata: third\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	start := time.Now()
+	ch, cancel := rx_go.NewSSE(server.Client(), req, time.Second*10).Subscribe()
+	defer cancel()
+
+	assert.Equal(t, rx_go.SSEEvent{ID: "1", Event: "update", Data: "first\nline", Retry: time.Millisecond * 50}, <-ch)
+	assert.Equal(t, rx_go.SSEEvent{ID: "2", Event: "message", Data: "second"}, <-ch)
+	assert.Equal(t, rx_go.SSEEvent{ID: "3", Event: "message", Data: "third"}, <-ch)
+	assert.True(t, time.Since(start) < time.Second)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, []string{"", "2"}, lastEventIDs)
+}
+
+func TestNewSSE_NoContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	res, err := collectErr(rx_go.NewSSE(server.Client(), req, 0))
+	assert.Empty(t, res)
+	assert.NoError(t, err)
+}
+
+func TestNewSSE_ContentType(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = fmt.Fprint(w, "<html>data: error</html>\n\n")
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	res, err := collectErr(rx_go.NewSSE(server.Client(), req, time.Millisecond*10))
+	assert.Empty(t, res)
+	assert.ErrorIs(t, err, rx_go.ErrNotEventStream)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestNewSSE_TransportError(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "unknown://example.com", nil)
+	sub := rx_go.NewSSE(http.DefaultClient, req, time.Millisecond*10).SubscribeWith(context.Background(), rx_go.Handlers[rx_go.SSEEvent]{})
+	select {
+	case <-sub.Done():
+	case <-time.After(time.Second):
+		t.Fatal("not retryable error was retried")
+	}
+	assert.Error(t, sub.Err())
+}
+
+func TestNewSSE_Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	res, err := collectErr(rx_go.NewSSE(server.Client(), req, 0))
+	assert.Empty(t, res)
+	assert.IsType(t, &rx_go.HttpStatusError{}, err)
+}
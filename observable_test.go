@@ -1,10 +1,13 @@
 package rx_go_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/PxyUp/rx_go"
 	"github.com/stretchr/testify/assert"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -69,6 +72,26 @@ func TestMapTo(t *testing.T) {
 	assert.Equal(t, []string{"hello 1", "hello 2", "hello 3", "hello 4", "hello 5", "hello 6"}, res)
 }
 
+func TestMapTo_Lazy(t *testing.T) {
+	var calls int32
+	obs := rx_go.MapTo(rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		atomic.AddInt32(&calls, 1)
+		emitter.Next(1)
+		emitter.Next(2)
+	}), func(t int) string {
+		return fmt.Sprintf("hello %d", t)
+	})
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+
+	for i := 0; i < 2; i++ {
+		res, err := collectErr(obs)
+		assert.Equal(t, []string{"hello 1", "hello 2"}, res)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
 func TestOf(t *testing.T) {
 	ch, _ := rx_go.Of("hello").Subscribe()
 	assert.Equal(t, "hello", <-ch)
@@ -131,6 +154,30 @@ func TestObservable_Subscribe(t *testing.T) {
 	assert.Equal(t, []int{1}, res)
 }
 
+func TestObservable_SubscribeErr(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.MapTo(rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		emitter.Next(1)
+		emitter.Error(err)
+	}), func(value int) int {
+		return value * 2
+	})
+	for i := 0; i < 2; i++ {
+		ch, errFn, _ := obs.SubscribeErr()
+		var res []int
+		for v := range ch {
+			res = append(res, v)
+		}
+		assert.Equal(t, []int{2}, res)
+		assert.Equal(t, err, errFn())
+	}
+
+	ch, errFn, _ := rx_go.From(1, 2).SubscribeErr()
+	for range ch {
+	}
+	assert.NoError(t, errFn())
+}
+
 func TestNewInterval(t *testing.T) {
 	ch, cancel := rx_go.NewInterval(time.Millisecond*300, true).Subscribe()
 	go func() {
@@ -160,6 +207,24 @@ func TestMerge(t *testing.T) {
 	assert.Len(t, res, 7)
 }
 
+func TestMerge_Error(t *testing.T) {
+	err := errors.New("failed")
+	stopped := make(chan struct{})
+	never := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		defer close(stopped)
+		<-ctx.Done()
+	})
+
+	res, obsErr := collectErr(rx_go.Merge(rx_go.Throw[int](err), never))
+	assert.Empty(t, res)
+	assert.Equal(t, err, obsErr)
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("other observable was not unsubscribed")
+	}
+}
+
 func TestFromChannel(t *testing.T) {
 	intChan := make(chan int)
 	go func() {
@@ -177,3 +242,564 @@ func TestFromChannel(t *testing.T) {
 	assert.Contains(t, res, 2)
 	assert.Len(t, res, 2)
 }
+
+func TestThrow(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.Throw[int](err)
+	ch, _ := obs.Subscribe()
+	_, ok := <-ch
+	assert.False(t, ok)
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestMapTo_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.MapTo(rx_go.Merge(rx_go.From(1, 2), rx_go.Throw[int](err)), func(t int) string {
+		return fmt.Sprintf("hello %d", t)
+	})
+	ch, _ := obs.Subscribe()
+	for range ch {
+	}
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestMapTo_ErrorCold(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.MapTo(rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		emitter.Error(err)
+	}), func(value int) string {
+		return fmt.Sprintf("hello %d", value)
+	})
+	assert.Nil(t, obs.Err())
+	ch, _ := obs.Subscribe()
+	for range ch {
+	}
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestForkJoin_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.ForkJoin(rx_go.From([]int{4, 5, 6}...), rx_go.Throw[int](err))
+	ch, _ := obs.Subscribe()
+	var res [][]int
+	for v := range ch {
+		res = append(res, v)
+	}
+	assert.Nil(t, res)
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestSwitch_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.Switch(rx_go.From([]int{1, 2, 3}...), func(value int) *rx_go.Observable[int] {
+		if value == 2 {
+			return rx_go.Throw[int](err)
+		}
+		return rx_go.Of(value)
+	})
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Equal(t, []int{1}, res)
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestObservable_SubscribeWith(t *testing.T) {
+	var res []int
+	completed := false
+	sub := rx_go.From([]int{1, 2, 3}...).SubscribeWith(context.Background(), rx_go.Handlers[int]{
+		OnNext: func(value int) {
+			res = append(res, value)
+		},
+		OnComplete: func() {
+			completed = true
+		},
+	})
+	<-sub.Done()
+	assert.Equal(t, []int{1, 2, 3}, res)
+	assert.True(t, completed)
+	assert.NoError(t, sub.Err())
+}
+
+func TestObservable_SubscribeWith_Error(t *testing.T) {
+	err := errors.New("failed")
+	var handledErr error
+	sub := rx_go.Throw[int](err).SubscribeWith(context.Background(), rx_go.Handlers[int]{
+		OnError: func(err error) {
+			handledErr = err
+		},
+	})
+	<-sub.Done()
+	assert.Equal(t, err, handledErr)
+	assert.Equal(t, err, sub.Err())
+}
+
+func TestObservable_SubscribeWith_Unsubscribe(t *testing.T) {
+	completed := false
+	sub := rx_go.NewInterval(time.Millisecond*100, true).SubscribeWith(context.Background(), rx_go.Handlers[time.Time]{
+		OnComplete: func() {
+			completed = true
+		},
+	})
+	assert.NoError(t, sub.Err())
+	sub.Unsubscribe()
+	<-sub.Done()
+	assert.False(t, completed)
+	assert.Equal(t, context.Canceled, sub.Err())
+}
+
+func TestObservable_SubscribeWith_UnsubscribeCreate(t *testing.T) {
+	obs := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		<-ctx.Done()
+		emitter.Error(ctx.Err())
+	})
+	for i := 0; i < 100; i++ {
+		failed := false
+		completed := false
+		sub := obs.SubscribeWith(context.Background(), rx_go.Handlers[int]{
+			OnError: func(err error) {
+				failed = true
+			},
+			OnComplete: func() {
+				completed = true
+			},
+		})
+		sub.Unsubscribe()
+		<-sub.Done()
+		assert.False(t, failed)
+		assert.False(t, completed)
+		assert.Equal(t, context.Canceled, sub.Err())
+	}
+}
+
+func TestDefer(t *testing.T) {
+	calls := 0
+	obs := rx_go.Defer(func() *rx_go.Observable[int] {
+		calls++
+		return rx_go.From([]int{1, 2, 3}...)
+	}).Pipe(rx_go.Map[int](func(value int) int {
+		return value * 2
+	}))
+	assert.Equal(t, 0, calls)
+	for i := 0; i < 2; i++ {
+		ch, _ := obs.Subscribe()
+		var res []int
+		for v := range ch {
+			res = append(res, v)
+		}
+		assert.Equal(t, []int{2, 4, 6}, res)
+	}
+	assert.Equal(t, 2, calls)
+}
+
+func TestCreate(t *testing.T) {
+	obs := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		for i := 1; i <= 3; i++ {
+			emitter.Next(i)
+		}
+	})
+	for i := 0; i < 2; i++ {
+		ch, _ := obs.Subscribe()
+		var res []int
+		for v := range ch {
+			res = append(res, v)
+		}
+		assert.Equal(t, []int{1, 2, 3}, res)
+	}
+}
+
+func TestCreate_Unsubscribe(t *testing.T) {
+	stopped := make(chan struct{})
+	obs := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				emitter.Next(i)
+			}
+		}
+	})
+	ch, cancel := obs.Subscribe()
+	assert.Equal(t, 0, <-ch)
+	assert.Equal(t, 1, <-ch)
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("producer was not stopped")
+	}
+}
+
+func TestCreate_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		emitter.Next(1)
+		emitter.Error(err)
+	})
+	for i := 0; i < 2; i++ {
+		res, obsErr := collectErr(obs)
+		assert.Equal(t, []int{1}, res)
+		assert.Equal(t, err, obsErr)
+	}
+}
+
+func TestRace(t *testing.T) {
+	stopped := make(chan struct{})
+	slow := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			emitter.Next(100)
+		}
+	})
+	fast := rx_go.From(1, 2, 3).Pipe(rx_go.InitialDelay[int](time.Millisecond * 50))
+
+	ch, _ := rx_go.Race(slow, fast).Subscribe()
+	var res []int
+	for v := range ch {
+		res = append(res, v)
+	}
+	assert.Equal(t, []int{1, 2, 3}, res)
+	select {
+	case <-stopped:
+	case <-time.After(time.Millisecond * 500):
+		t.Fatal("loser was not unsubscribed")
+	}
+}
+
+func TestRace_Error(t *testing.T) {
+	err := errors.New("failed")
+	never := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		<-ctx.Done()
+	})
+	_, obsErr := collectErr(rx_go.Race(rx_go.Throw[int](err), never))
+	assert.Equal(t, err, obsErr)
+}
+
+func TestCombineLatest(t *testing.T) {
+	config := rx_go.NewSubject[int]()
+	flags := rx_go.NewSubject[string]()
+
+	ch, _ := rx_go.CombineLatest2(config.Observable, flags.Observable).Subscribe()
+	config.Next(1)
+	flags.Next("a")
+	assert.Equal(t, rx_go.Tuple2[int, string]{V1: 1, V2: "a"}, <-ch)
+	config.Next(2)
+	assert.Equal(t, rx_go.Tuple2[int, string]{V1: 2, V2: "a"}, <-ch)
+	flags.Next("b")
+	assert.Equal(t, rx_go.Tuple2[int, string]{V1: 2, V2: "b"}, <-ch)
+
+	config.Complete()
+	flags.Next("c")
+	assert.Equal(t, rx_go.Tuple2[int, string]{V1: 2, V2: "c"}, <-ch)
+	flags.Complete()
+	_, ok := <-ch
+	assert.False(t, ok)
+}
+
+func TestCombineLatest_Slice(t *testing.T) {
+	ch, _ := rx_go.CombineLatest(rx_go.Of(1), rx_go.Of(2), rx_go.Of(3)).Subscribe()
+	assert.Equal(t, [][]int{{1, 2, 3}}, collect(ch))
+
+	ch, _ = rx_go.CombineLatest(rx_go.Of(1), rx_go.From[int]()).Subscribe()
+	assert.Empty(t, collect(ch))
+}
+
+func TestCombineLatest_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.CombineLatest3(rx_go.Of(1), rx_go.Of("a"), rx_go.Throw[bool](err))
+	ch, _ := obs.Subscribe()
+	assert.Empty(t, collect(ch))
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestZip(t *testing.T) {
+	ch, _ := rx_go.Zip2(rx_go.From(1, 2, 3), rx_go.From("a", "b")).Subscribe()
+	assert.Equal(t, []rx_go.Tuple2[int, string]{{V1: 1, V2: "a"}, {V1: 2, V2: "b"}}, collect(ch))
+
+	ch3, _ := rx_go.Zip3(rx_go.From(1, 2), rx_go.From("a", "b"), rx_go.From(true, false)).Subscribe()
+	assert.Equal(t, []rx_go.Tuple3[int, string, bool]{{V1: 1, V2: "a", V3: true}, {V1: 2, V2: "b", V3: false}}, collect(ch3))
+}
+
+func TestZipAll(t *testing.T) {
+	ch, _ := rx_go.ZipAll(rx_go.From(1, 2, 3), rx_go.From(4, 5, 6), rx_go.From(7, 8, 9)).Subscribe()
+	assert.Equal(t, [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}, collect(ch))
+}
+
+func TestZipAll_Shortest(t *testing.T) {
+	infinite := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		for i := 0; ctx.Err() == nil; i++ {
+			emitter.Next(i)
+		}
+	})
+	slow := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		<-ctx.Done()
+	})
+	short := rx_go.From(10)
+
+	ch, _ := rx_go.ZipAll(infinite, short).Subscribe()
+	assert.Equal(t, [][]int{{0, 10}}, collect(ch))
+
+	// completes without waiting for value of slow observable
+	ch, _ = rx_go.ZipAll(slow, rx_go.From[int]()).Subscribe()
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("zip was not completed")
+	}
+}
+
+func TestZipAll_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.ZipAll(rx_go.From(1, 2), rx_go.Throw[int](err))
+	ch, _ := obs.Subscribe()
+	assert.Empty(t, collect(ch))
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestWithLatestFrom(t *testing.T) {
+	events := rx_go.NewSubject[int]()
+	config := rx_go.NewSubject[string]()
+
+	obs := rx_go.WithLatestFrom(events.Observable, config.Observable, func(event int, config string) string {
+		return fmt.Sprintf("%d-%s", event, config)
+	})
+	ch, sub := subscribeErr(obs)
+
+	events.Next(1)
+	time.Sleep(time.Millisecond * 50)
+	config.Next("a")
+	time.Sleep(time.Millisecond * 50)
+	events.Next(2)
+	assert.Equal(t, "2-a", <-ch)
+	config.Next("b")
+	time.Sleep(time.Millisecond * 50)
+	events.Next(3)
+	assert.Equal(t, "3-b", <-ch)
+
+	config.Complete()
+	events.Next(4)
+	assert.Equal(t, "4-b", <-ch)
+	events.Complete()
+	_, ok := <-ch
+	assert.False(t, ok)
+	assert.NoError(t, sub.Err())
+}
+
+func TestWithLatestFrom_Error(t *testing.T) {
+	err := errors.New("failed")
+	never := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		<-ctx.Done()
+	})
+	res, obsErr := collectErr(rx_go.WithLatestFrom(never, rx_go.Throw[int](err), func(a int, b int) int {
+		return a + b
+	}))
+	assert.Empty(t, res)
+	assert.Equal(t, err, obsErr)
+}
+
+func TestSequence(t *testing.T) {
+	var subscribed int32
+	second := rx_go.Defer(func() *rx_go.Observable[int] {
+		atomic.AddInt32(&subscribed, 1)
+		return rx_go.From(3, 4)
+	})
+
+	ch, _ := rx_go.Sequence(rx_go.From(1, 2).Pipe(rx_go.Delay[int](time.Millisecond*10)), second).Subscribe()
+	assert.Equal(t, 1, <-ch)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&subscribed))
+	assert.Equal(t, []int{2, 3, 4}, collect(ch))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&subscribed))
+}
+
+func TestSequence_Unsubscribe(t *testing.T) {
+	var subscribed int32
+	pending := rx_go.Defer(func() *rx_go.Observable[int] {
+		atomic.AddInt32(&subscribed, 1)
+		return rx_go.From(3)
+	})
+	stopped := make(chan struct{})
+	infinite := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		defer close(stopped)
+		for i := 0; ctx.Err() == nil; i++ {
+			emitter.Next(i)
+		}
+	})
+
+	hot := rx_go.NewObserver[int]()
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		hot.Next(4)
+		hot.Complete()
+	}()
+
+	ch, cancel := rx_go.Sequence(infinite, pending, rx_go.New(hot)).Subscribe()
+	assert.Equal(t, 0, <-ch)
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("current observable was not unsubscribed")
+	}
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("pending hot observable was not completed")
+	}
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&subscribed))
+}
+
+func TestSequence_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.Sequence(rx_go.From(1), rx_go.Throw[int](err), rx_go.From(2))
+	ch, _ := obs.Subscribe()
+	assert.Equal(t, []int{1}, collect(ch))
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestConcatMap(t *testing.T) {
+	ch, _ := rx_go.ConcatMap(rx_go.From(1, 2, 3), func(value int) *rx_go.Observable[int] {
+		return rx_go.From(value, value*10).Pipe(rx_go.Delay[int](time.Millisecond * 10))
+	}).Subscribe()
+	assert.Equal(t, []int{1, 10, 2, 20, 3, 30}, collect(ch))
+}
+
+func TestSwitchMap(t *testing.T) {
+	outer := rx_go.NewSubject[int]()
+	var cancelled int32
+	obs := rx_go.SwitchMap(outer.Observable, func(value int) *rx_go.Observable[string] {
+		return rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[string]) {
+			for i := 0; ; i++ {
+				select {
+				case <-ctx.Done():
+					atomic.AddInt32(&cancelled, 1)
+					return
+				case <-time.After(time.Millisecond * 20):
+					if i == 2 {
+						return
+					}
+					emitter.Next(fmt.Sprintf("%d-%d", value, i))
+				}
+			}
+		})
+	})
+	ch, sub := subscribeErr(obs)
+
+	outer.Next(1)
+	assert.Equal(t, "1-0", <-ch)
+	outer.Next(2)
+	assert.Equal(t, "2-0", <-ch)
+	outer.Complete()
+	assert.Equal(t, []string{"2-1"}, collect(ch))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&cancelled))
+	assert.NoError(t, sub.Err())
+}
+
+func TestSwitchMap_Order(t *testing.T) {
+	outer := rx_go.NewSubject[int]()
+	obs := rx_go.SwitchMap(outer.Observable, func(value int) *rx_go.Observable[int] {
+		return rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+			for ctx.Err() == nil {
+				emitter.Next(value)
+			}
+		})
+	})
+	ch, cancel := obs.Subscribe()
+	defer cancel()
+
+	latest := 0
+	for i := 1; i <= 50; i++ {
+		outer.Next(i)
+		// values of previous observable are not emitted after switching
+		for value := range ch {
+			assert.GreaterOrEqual(t, value, latest)
+			latest = value
+			if value == i {
+				break
+			}
+		}
+	}
+}
+
+func TestSwitchMap_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.SwitchMap(rx_go.From(1, 2, 3), func(value int) *rx_go.Observable[int] {
+		if value == 3 {
+			return rx_go.Throw[int](err)
+		}
+		return rx_go.Of(value)
+	})
+	ch, _ := obs.Subscribe()
+	for range ch {
+	}
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestMergeMap(t *testing.T) {
+	var active, maxActive int32
+	obs := rx_go.MergeMap(rx_go.From(1, 2, 3, 4, 5), func(value int) *rx_go.Observable[int] {
+		return rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+			current := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+			for {
+				prev := atomic.LoadInt32(&maxActive)
+				if current <= prev || atomic.CompareAndSwapInt32(&maxActive, prev, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond * 20)
+			emitter.Next(value)
+			emitter.Next(value * 10)
+		})
+	}, 2)
+	ch, _ := obs.Subscribe()
+	assert.ElementsMatch(t, []int{1, 10, 2, 20, 3, 30, 4, 40, 5, 50}, collect(ch))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxActive))
+	assert.Nil(t, obs.Err())
+}
+
+func TestMergeMap_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.MergeMap(rx_go.From(1, 2, 3), func(value int) *rx_go.Observable[int] {
+		if value == 2 {
+			return rx_go.Throw[int](err)
+		}
+		return rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+			<-ctx.Done()
+		})
+	}, 0)
+	ch, _ := obs.Subscribe()
+	for range ch {
+	}
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestExhaustMap(t *testing.T) {
+	clicks := rx_go.NewSubject[int]()
+	var mapped int32
+	obs := rx_go.ExhaustMap(clicks.Observable, func(value int) *rx_go.Observable[int] {
+		atomic.AddInt32(&mapped, 1)
+		return rx_go.From(value).Pipe(rx_go.InitialDelay[int](time.Millisecond * 100))
+	})
+	ch, sub := subscribeErr(obs)
+
+	clicks.Next(1)
+	time.Sleep(time.Millisecond * 20)
+	clicks.Next(2)
+	clicks.Next(3)
+	assert.Equal(t, 1, <-ch)
+	time.Sleep(time.Millisecond * 20)
+	clicks.Next(4)
+	clicks.Complete()
+	assert.Equal(t, []int{4}, collect(ch))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&mapped))
+	assert.NoError(t, sub.Err())
+}
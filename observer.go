@@ -5,12 +5,16 @@ import (
 )
 
 type Observer[T any] struct {
-	list chan T
+	list     chan T
+	done     chan struct{}
+	doneOnce sync.Once
 
 	onComplete  func()
 	onSubscribe func()
 	onNext      func(value T)
 
+	err       error
+	errMutex  sync.RWMutex
 	completed bool
 	mutex     sync.Mutex
 }
@@ -41,6 +45,7 @@ func StaticObserver[T any](value T) *Observer[T] {
 func NewObserver[T any]() *Observer[T] {
 	return &Observer[T]{
 		list:        make(chan T),
+		done:        make(chan struct{}),
 		onComplete:  func() {},
 		onSubscribe: func() {},
 		onNext:      func(v T) {},
@@ -72,17 +77,48 @@ func (o *Observer[T]) Next(value T) {
 	if o.completed {
 		return
 	}
-	o.list <- value
-	o.onNext(value)
+	select {
+	case o.list <- value:
+		o.onNext(value)
+	case <-o.done:
+	}
 }
 
 func (o *Observer[T]) Complete() {
+	o.complete(nil)
+}
+
+// Error - complete observer with error, all next values will be ignored
+func (o *Observer[T]) Error(err error) {
+	o.complete(err)
+}
+
+// Err - return error which completed the observer, nil if observer completed without error or still active
+func (o *Observer[T]) Err() error {
+	o.errMutex.RLock()
+	defer o.errMutex.RUnlock()
+	return o.err
+}
+
+// completeWith - complete observer with error returned by errFn or without error if it is nil
+func (o *Observer[T]) completeWith(errFn func() error) {
+	o.complete(errFn())
+}
+
+func (o *Observer[T]) complete(err error) {
+	// unblock Next which is waiting for reader, otherwise completion will wait for it forever
+	o.doneOnce.Do(func() {
+		close(o.done)
+	})
 	o.mutex.Lock()
 	defer o.mutex.Unlock()
 	if o.completed {
 		return
 	}
 	o.completed = true
+	o.errMutex.Lock()
+	o.err = err
+	o.errMutex.Unlock()
 	if o.onComplete != nil {
 		o.onComplete()
 	}
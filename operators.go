@@ -2,16 +2,21 @@ package rx_go
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 )
 
+// ErrTimeout - error of Timeout and TimeoutFirst operators
+var ErrTimeout = errors.New("rx_go: timeout")
+
 // Find - only the first value emitted by the source Observable that meets some condition.
 func Find[T any](fn func(T) bool) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
+			defer observer.recoverPanic(obs.Complete)
 			emitted := false
 			for val := range obs.list {
 				local := val
@@ -31,7 +36,7 @@ func ElementAt[T any](index uint32) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
 			i := 0
 			for val := range obs.list {
 				if i == int(index) {
@@ -50,7 +55,8 @@ func Finally[T any](fn func()) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
+			defer observer.recoverPanic(obs.Complete)
 			defer fn()
 			for val := range obs.list {
 				observer.Next(val)
@@ -66,11 +72,13 @@ func EndWith[T any](value T) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
-			defer observer.Complete()
-			defer observer.Next(value)
+			defer observer.completeWith(obs.Err)
 			for val := range obs.list {
 				observer.Next(val)
 			}
+			if obs.Err() == nil {
+				observer.Next(value)
+			}
 
 		}()
 		return observer
@@ -82,7 +90,7 @@ func StartWith[T any](value T) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
 			observer.Next(value)
 			for val := range obs.list {
 				observer.Next(val)
@@ -98,7 +106,7 @@ func Repeat[T any](times uint32) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
 			for value := range obs.list {
 				local := value
 				count := times
@@ -121,7 +129,7 @@ func Debounce[T any](duration time.Duration) Operator[T] {
 			var wg sync.WaitGroup
 			defer func() {
 				wg.Wait()
-				observer.Complete()
+				observer.completeWith(obs.Err)
 			}()
 			var timer *time.Timer
 			for value := range obs.list {
@@ -147,7 +155,8 @@ func Do[T any](fn func(value T)) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
+			defer observer.recoverPanic(obs.Complete)
 			observer.onNext = fn
 			for value := range obs.list {
 				observer.Next(value)
@@ -162,7 +171,7 @@ func SkipUntilCtx[T any](ctx context.Context) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
 
 			for {
 				value, ok := <-obs.list
@@ -186,8 +195,8 @@ func SkipUntil[T any, Y any](o *Observable[Y]) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
-			defer observer.Complete()
-			ch, cancel := o.Subscribe()
+			defer observer.completeWith(obs.Err)
+			ch, source, cancel := o.subscribe(context.Background())
 
 			observer.SetOnComplete(func() {
 				cancel()
@@ -197,6 +206,10 @@ func SkipUntil[T any, Y any](o *Observable[Y]) Operator[T] {
 
 			go func() {
 				<-ch
+				if err := source.Err(); err != nil {
+					observer.Error(err)
+					return
+				}
 				close(emitted)
 			}()
 
@@ -221,8 +234,10 @@ func SkipUntil[T any, Y any](o *Observable[Y]) Operator[T] {
 func Skip[T any](count uint32) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
+		// operator is called for each subscription of cold observable, so counter should not be shared between them
+		count := count
 		go func() {
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
 			for v := range obs.list {
 				if count > 0 {
 					count--
@@ -240,7 +255,7 @@ func AfterCtx[T any](ctx context.Context) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
 			for {
 				value, ok := <-obs.list
 				if !ok {
@@ -260,7 +275,7 @@ func UntilCtx[T any](ctx context.Context) Operator[T] {
 		observer := NewObserver[T]()
 		go func() {
 			defer obs.Complete()
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
 			for {
 				select {
 				case <-ctx.Done():
@@ -282,6 +297,7 @@ func DistinctWith[T any](comp func(T, T) bool) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
+			defer observer.recoverPanic(obs.Complete)
 			var prev *T
 			for value := range obs.list {
 				local := value
@@ -295,7 +311,7 @@ func DistinctWith[T any](comp func(T, T) bool) Operator[T] {
 					}
 				}
 			}
-			observer.Complete()
+			observer.completeWith(obs.Err)
 		}()
 		return observer
 	}
@@ -312,9 +328,11 @@ func Distinct[T comparable]() Operator[T] {
 func Take[T any](count int) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
+		// operator is called for each subscription of cold observable, so counter should not be shared between them
+		count := count
 		go func() {
 			defer obs.Complete()
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
 			for value := range obs.list {
 				if count > 0 {
 					observer.Next(value)
@@ -338,7 +356,7 @@ func InitialDelay[T any](delay time.Duration) Operator[T] {
 			for v := range obs.list {
 				observer.Next(v)
 			}
-			observer.Complete()
+			observer.completeWith(obs.Err)
 		}()
 		return observer
 	}
@@ -353,7 +371,7 @@ func Delay[T any](delay time.Duration) Operator[T] {
 				time.Sleep(delay)
 				observer.Next(v)
 			}
-			observer.Complete()
+			observer.completeWith(obs.Err)
 		}()
 		return observer
 	}
@@ -370,13 +388,13 @@ func LastOne[T any]() Operator[T] {
 		observer := NewObserver[T]()
 
 		go func() {
-			defer observer.Complete()
+			defer observer.completeWith(obs.Err)
 			var last *T
 			for value := range obs.list {
 				local := value
 				last = &local
 			}
-			if last != nil {
+			if last != nil && obs.Err() == nil {
 				observer.Next(*last)
 			}
 		}()
@@ -389,12 +407,13 @@ func Filter[T any](flt func(value T) bool) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
+			defer observer.recoverPanic(obs.Complete)
 			for value := range obs.list {
 				if flt(value) {
 					observer.Next(value)
 				}
 			}
-			observer.Complete()
+			observer.completeWith(obs.Err)
 		}()
 		return observer
 	}
@@ -405,10 +424,169 @@ func Map[T any](mapper func(value T) T) Operator[T] {
 	return func(obs *Observer[T]) *Observer[T] {
 		observer := NewObserver[T]()
 		go func() {
+			defer observer.recoverPanic(obs.Complete)
 			for value := range obs.list {
 				observer.Next(mapper(value))
 			}
-			observer.Complete()
+			observer.completeWith(obs.Err)
+		}()
+		return observer
+	}
+}
+
+// CatchError - switch to the observable returned by fn when source completes with error
+func CatchError[T any](fn func(err error) *Observable[T]) Operator[T] {
+	return func(obs *Observer[T]) *Observer[T] {
+		observer := NewObserver[T]()
+		go func() {
+			defer observer.recoverPanic(obs.Complete)
+			for value := range obs.list {
+				observer.Next(value)
+			}
+			err := obs.Err()
+			if err == nil {
+				observer.Complete()
+				return
+			}
+			observer.complete(follow(observer, fn(err)))
+		}()
+		return observer
+	}
+}
+
+// OnErrorReturn - emit value and complete when source completes with error
+func OnErrorReturn[T any](value T) Operator[T] {
+	return CatchError(func(_ error) *Observable[T] {
+		return Of(value)
+	})
+}
+
+// OnErrorResumeNext - continue with next observable when source completes with or without error, errors are ignored.
+// After unsubscribe current observable is unsubscribed and pending ones are discarded
+func OnErrorResumeNext[T any](obss ...*Observable[T]) Operator[T] {
+	return func(obs *Observer[T]) *Observer[T] {
+		observer := NewObserver[T]()
+		go func() {
+			next := 0
+			defer func() {
+				for _, o := range obss[next:] {
+					o.discard()
+				}
+			}()
+			defer observer.Complete()
+
+			for value := range obs.list {
+				observer.Next(value)
+			}
+			for next < len(obss) {
+				select {
+				case <-observer.done:
+					return
+				default:
+				}
+
+				o := obss[next]
+				next++
+				// error only switches to the next observable
+				_ = follow(observer, o)
+			}
+		}()
+		return observer
+	}
+}
+
+// follow - emit all values of the observable to the observer and return error of the observable, subscription is cancelled if observer is completed
+func follow[T any](observer *Observer[T], o *Observable[T]) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, source, _ := o.subscribe(ctx)
+	go func() {
+		select {
+		case <-observer.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for value := range ch {
+		observer.Next(value)
+	}
+	return source.Err()
+}
+
+// Timeout - complete with ErrTimeout if first value or next value is not emitted in provided duration
+func Timeout[T any](duration time.Duration) Operator[T] {
+	return timeout[T](duration, true, nil)
+}
+
+// TimeoutFirst - complete with ErrTimeout if first value is not emitted in provided duration
+func TimeoutFirst[T any](duration time.Duration) Operator[T] {
+	return timeout[T](duration, false, nil)
+}
+
+// TimeoutWith - switch to fallback observable if first value or next value is not emitted in provided duration
+func TimeoutWith[T any](duration time.Duration, fallback *Observable[T]) Operator[T] {
+	return timeout(duration, true, fallback)
+}
+
+func timeout[T any](duration time.Duration, each bool, fallback *Observable[T]) Operator[T] {
+	return func(obs *Observer[T]) *Observer[T] {
+		observer := NewObserver[T]()
+
+		// timer is started after subscription, hot source can be piped long before it is subscribed
+		waiting := make(chan struct{})
+		var subscribed sync.Once
+		observer.SetOnSubscribe(func() {
+			subscribed.Do(func() {
+				close(waiting)
+			})
+		})
+
+		go func() {
+			select {
+			case <-waiting:
+			case <-observer.done:
+				obs.Complete()
+				return
+			}
+
+			timer := time.NewTimer(duration)
+			defer timer.Stop()
+			timerCh := timer.C
+
+			for {
+				select {
+				case <-observer.done:
+					obs.Complete()
+					return
+				case value, ok := <-obs.list:
+					if !ok {
+						observer.completeWith(obs.Err)
+						return
+					}
+					observer.Next(value)
+					if !timer.Stop() {
+						select {
+						case <-timer.C:
+						default:
+						}
+					}
+					if !each {
+						timerCh = nil
+						continue
+					}
+					timer.Reset(duration)
+				case <-timerCh:
+					obs.Complete()
+					if fallback == nil {
+						observer.Error(ErrTimeout)
+						return
+					}
+					observer.complete(follow(observer, fallback))
+					return
+				}
+			}
 		}()
 		return observer
 	}
@@ -2,9 +2,11 @@ package rx_go_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/PxyUp/rx_go"
 	"github.com/stretchr/testify/assert"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -264,6 +266,23 @@ func TestTake(t *testing.T) {
 	assert.Equal(t, []int{1, 2, 3}, res)
 }
 
+func TestTakeSkip_Cold(t *testing.T) {
+	obs := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		for i := 1; i <= 5; i++ {
+			emitter.Next(i)
+		}
+	}).Pipe(rx_go.Take[int](2), rx_go.Skip[int](1))
+
+	for i := 0; i < 3; i++ {
+		ch, _ := obs.Subscribe()
+		var res []int
+		for val := range ch {
+			res = append(res, val)
+		}
+		assert.Equal(t, []int{2}, res)
+	}
+}
+
 func TestFirstOne(t *testing.T) {
 	values := []int{1, 2, 3, 4, 5, 6}
 	obs := rx_go.From(values...)
@@ -326,3 +345,207 @@ func TestFilterMap(t *testing.T) {
 	}
 	assert.Equal(t, []int{18}, res)
 }
+
+func TestMap_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.Throw[int](err).Pipe(rx_go.Map[int](func(value int) int {
+		return value * 3
+	}), rx_go.Filter[int](func(value int) bool {
+		return value > 16
+	}))
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Nil(t, res)
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestEndWith_Error(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.Throw[int](err).Pipe(rx_go.EndWith(2), rx_go.LastOne[int]())
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Nil(t, res)
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestCatchError(t *testing.T) {
+	err := errors.New("failed")
+	var caught error
+	obs := rx_go.Merge(rx_go.Of(1), rx_go.Throw[int](err).Pipe(rx_go.InitialDelay[int](time.Millisecond*100))).Pipe(
+		rx_go.CatchError(func(e error) *rx_go.Observable[int] {
+			caught = e
+			return rx_go.From([]int{2, 3}...)
+		}),
+	)
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Equal(t, []int{1, 2, 3}, res)
+	assert.Equal(t, err, caught)
+	assert.NoError(t, obs.Err())
+}
+
+func TestCatchError_Rethrow(t *testing.T) {
+	err := errors.New("failed")
+	obs := rx_go.Throw[int](errors.New("source")).Pipe(rx_go.CatchError(func(_ error) *rx_go.Observable[int] {
+		return rx_go.Throw[int](err)
+	}))
+	ch, _ := obs.Subscribe()
+	for range ch {
+	}
+	assert.Equal(t, err, obs.Err())
+}
+
+func TestOnErrorReturn(t *testing.T) {
+	obs := rx_go.Throw[int](errors.New("failed")).Pipe(rx_go.OnErrorReturn(5))
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Equal(t, []int{5}, res)
+	assert.NoError(t, obs.Err())
+}
+
+func TestOnErrorResumeNext(t *testing.T) {
+	obs := rx_go.From([]int{1}...).Pipe(rx_go.OnErrorResumeNext(
+		rx_go.Throw[int](errors.New("failed")),
+		rx_go.From([]int{2, 3}...),
+	))
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Equal(t, []int{1, 2, 3}, res)
+	assert.NoError(t, obs.Err())
+}
+
+func TestOnErrorResumeNext_Unsubscribe(t *testing.T) {
+	var subscribed int32
+	pending := rx_go.Defer(func() *rx_go.Observable[int] {
+		atomic.AddInt32(&subscribed, 1)
+		return rx_go.From(2)
+	})
+	stopped := make(chan struct{})
+	infinite := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		defer close(stopped)
+		for i := 0; ctx.Err() == nil; i++ {
+			emitter.Next(i)
+		}
+	})
+
+	ch, cancel := rx_go.Of(1).Pipe(rx_go.OnErrorResumeNext(infinite, pending)).Subscribe()
+	assert.Equal(t, 1, <-ch)
+	assert.Equal(t, 0, <-ch)
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("current observable was not unsubscribed")
+	}
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&subscribed))
+}
+
+func TestTimeout(t *testing.T) {
+	obs := rx_go.From([]int{1, 2, 3}...).Pipe(
+		rx_go.Delay[int](time.Millisecond*50),
+		rx_go.Timeout[int](time.Millisecond*200),
+	)
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Equal(t, []int{1, 2, 3}, res)
+	assert.NoError(t, obs.Err())
+}
+
+func TestTimeout_Error(t *testing.T) {
+	obs := rx_go.NewInterval(time.Millisecond*300, true).Pipe(rx_go.Timeout[time.Time](time.Millisecond * 100))
+	ch, _ := obs.Subscribe()
+	var res []time.Time
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Len(t, res, 1)
+	assert.ErrorIs(t, obs.Err(), rx_go.ErrTimeout)
+}
+
+func TestTimeout_Unsubscribe(t *testing.T) {
+	source := rx_go.NewObserver[int]()
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		for i := 0; i < 5; i++ {
+			source.Next(i)
+		}
+		source.Complete()
+	}()
+
+	ch, cancel := rx_go.New(source).Pipe(rx_go.Timeout[int](time.Second)).Subscribe()
+	assert.Equal(t, 0, <-ch)
+	cancel()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("source was not completed after unsubscribe")
+	}
+}
+
+func TestTimeoutFirst(t *testing.T) {
+	obs := rx_go.From([]int{1, 2}...).Pipe(
+		rx_go.InitialDelay[int](time.Millisecond*50),
+		rx_go.Delay[int](time.Millisecond*150),
+		rx_go.TimeoutFirst[int](time.Millisecond*300),
+	)
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Equal(t, []int{1, 2}, res)
+	assert.NoError(t, obs.Err())
+
+	obs = rx_go.From([]int{1, 2}...).Pipe(
+		rx_go.InitialDelay[int](time.Millisecond*200),
+		rx_go.TimeoutFirst[int](time.Millisecond*100),
+	)
+	ch, _ = obs.Subscribe()
+	for range ch {
+	}
+	assert.ErrorIs(t, obs.Err(), rx_go.ErrTimeout)
+}
+
+func TestTimeoutWith(t *testing.T) {
+	obs := rx_go.Merge(rx_go.Of(1), rx_go.Of(2).Pipe(rx_go.InitialDelay[int](time.Millisecond*300))).Pipe(
+		rx_go.TimeoutWith(time.Millisecond*100, rx_go.From([]int{5, 6}...)),
+	)
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Equal(t, []int{1, 5, 6}, res)
+	assert.NoError(t, obs.Err())
+}
+
+func TestTimeoutFirst_DelayedSubscribe(t *testing.T) {
+	obs := rx_go.NewInterval(time.Millisecond*30, true).Pipe(rx_go.TimeoutFirst[time.Time](time.Millisecond * 100))
+	time.Sleep(time.Millisecond * 200)
+
+	ch, cancel := obs.Subscribe()
+	_, ok := <-ch
+	assert.True(t, ok)
+	cancel()
+	assert.NoError(t, obs.Err())
+}
@@ -0,0 +1,65 @@
+package rx_go
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+var (
+	panicHandler      func(err *PanicError)
+	panicHandlerMutex sync.RWMutex
+)
+
+// PanicError - error created from recovered panic of user function, it completes only affected observable
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("rx_go: recovered panic: %v", e.Value)
+}
+
+// Unwrap - return panic value if it is error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// SetPanicHandler - set global handler which is called for each recovered panic, nil removes handler
+func SetPanicHandler(fn func(err *PanicError)) {
+	panicHandlerMutex.Lock()
+	defer panicHandlerMutex.Unlock()
+	panicHandler = fn
+}
+
+func newPanicError(value any) *PanicError {
+	err := &PanicError{
+		Value: value,
+		Stack: debug.Stack(),
+	}
+
+	panicHandlerMutex.RLock()
+	handler := panicHandler
+	panicHandlerMutex.RUnlock()
+	if handler != nil {
+		handler(err)
+	}
+
+	return err
+}
+
+// recoverPanic - complete observer with PanicError if goroutine panics, cleanup is called before for cancelling the source. Should be called with defer
+func (o *Observer[T]) recoverPanic(cleanup func()) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	err := newPanicError(r)
+	if cleanup != nil {
+		cleanup()
+	}
+	o.Error(err)
+}
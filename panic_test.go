@@ -0,0 +1,90 @@
+package rx_go_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/rx_go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPanic_Operator(t *testing.T) {
+	obs := rx_go.From([]int{1, 2, 3}...).Pipe(rx_go.Map[int](func(value int) int {
+		if value == 2 {
+			panic("boom")
+		}
+		return value
+	}))
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Equal(t, []int{1}, res)
+
+	var panicErr *rx_go.PanicError
+	assert.True(t, errors.As(obs.Err(), &panicErr))
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+}
+
+func TestPanic_MapTo(t *testing.T) {
+	err := errors.New("boom")
+	obs := rx_go.MapTo(rx_go.From([]int{1, 2, 3}...), func(value int) string {
+		panic(err)
+	})
+	ch, _ := obs.Subscribe()
+	for range ch {
+	}
+	assert.ErrorIs(t, obs.Err(), err)
+}
+
+func TestPanic_Create(t *testing.T) {
+	obs := rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		emitter.Next(1)
+		panic("boom")
+	})
+	ch, _ := obs.Subscribe()
+	var res []int
+	for val := range ch {
+		res = append(res, val)
+	}
+	assert.Equal(t, []int{1}, res)
+	assert.IsType(t, &rx_go.PanicError{}, obs.Err())
+}
+
+func TestPanic_Defer(t *testing.T) {
+	obs := rx_go.Defer(func() *rx_go.Observable[int] {
+		panic("boom")
+	})
+	for i := 0; i < 2; i++ {
+		res, err := collectErr(obs)
+		assert.Empty(t, res)
+		assert.IsType(t, &rx_go.PanicError{}, err)
+	}
+}
+
+func TestPanic_SubscribeWith(t *testing.T) {
+	sub := rx_go.From([]int{1, 2, 3}...).SubscribeWith(context.Background(), rx_go.Handlers[int]{
+		OnNext: func(value int) {
+			panic("boom")
+		},
+	})
+	<-sub.Done()
+	assert.IsType(t, &rx_go.PanicError{}, sub.Err())
+}
+
+func TestSetPanicHandler(t *testing.T) {
+	handled := make(chan *rx_go.PanicError, 1)
+	rx_go.SetPanicHandler(func(err *rx_go.PanicError) {
+		handled <- err
+	})
+	defer rx_go.SetPanicHandler(nil)
+
+	ch, _ := rx_go.From([]int{1}...).Pipe(rx_go.Filter[int](func(value int) bool {
+		panic("boom")
+	})).Subscribe()
+	for range ch {
+	}
+	assert.Equal(t, "boom", (<-handled).Value)
+}
@@ -0,0 +1,124 @@
+package rx_go
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// DefaultRetryInterval - delay before first retry of Retry if InitialInterval is zero
+const DefaultRetryInterval = time.Millisecond * 100
+
+// RetryConfig - configuration of Retry, zero config retry source forever with DefaultRetryInterval delay
+type RetryConfig struct {
+	// MaxAttempts - max amount of subscriptions to the source, zero means unlimited
+	MaxAttempts uint32
+	// InitialInterval - delay before first retry, DefaultRetryInterval if zero
+	InitialInterval time.Duration
+	// MaxInterval - max delay between retries, zero means no limit(delay is capped by max time.Duration)
+	MaxInterval time.Duration
+	// Multiplier - delay is multiplied by it after each retry, values less than 1 means constant delay
+	Multiplier float64
+	// Jitter - randomization factor from 0 to 1, delay is randomized in range [delay * (1 - Jitter), delay * (1 + Jitter)]
+	Jitter float64
+	// ShouldRetry - return true if error is retryable, all errors are retryable if it is nil
+	ShouldRetry func(err error) bool
+}
+
+// delay - return delay before retry with provided number(starting from 1)
+func (c RetryConfig) delay(retry uint32) time.Duration {
+	delay := float64(c.InitialInterval)
+	if c.InitialInterval <= 0 {
+		delay = float64(DefaultRetryInterval)
+	}
+	if c.Multiplier > 1 {
+		delay *= math.Pow(c.Multiplier, float64(retry-1))
+	}
+	// delay grows to +Inf after many retries, conversion of too big float into time.Duration overflows into negative delay
+	maxDelay := float64(math.MaxInt64)
+	if c.MaxInterval > 0 {
+		maxDelay = float64(c.MaxInterval)
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if c.Jitter > 0 {
+		delay += delay * c.Jitter * (2*rand.Float64() - 1)
+	}
+	if delay >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	if delay < 0 {
+		return 0
+	}
+	return time.Duration(delay)
+}
+
+// Retry - resubscribe to the source when it completes with error, source should be cold(see Defer and Create) for producing new sequence.
+// Error of hot source is emitted without retry because resubscription return same completed observer
+func Retry[T any](o *Observable[T], config RetryConfig) *Observable[T] {
+	return Create(func(ctx context.Context, emitter Emitter[T]) {
+		for attempt := uint32(1); ; attempt++ {
+			ch, source, cancel := o.subscribe(ctx)
+			for value := range ch {
+				emitter.Next(value)
+			}
+			cancel()
+
+			err := source.Err()
+			if err == nil || ctx.Err() != nil {
+				return
+			}
+
+			if !o.cold() || (config.MaxAttempts > 0 && attempt >= config.MaxAttempts) || (config.ShouldRetry != nil && !config.ShouldRetry(err)) {
+				emitter.Error(err)
+				return
+			}
+
+			timer := time.NewTimer(config.delay(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	})
+}
+
+// RetryWhen - resubscribe to the source when notifier emit value after source error, errors of the source are emitted to notifier input.
+// Result observable completes if notifier completes and fails if notifier fails, error of hot source is emitted without retry
+func RetryWhen[T any, Y any](o *Observable[T], notifier func(errs *Observable[error]) *Observable[Y]) *Observable[T] {
+	return Create(func(ctx context.Context, emitter Emitter[T]) {
+		errs := NewSubject[error]()
+		notifierCh, notifierSource, cancelNotifier := notifier(errs.Observable).subscribe(ctx)
+		defer cancelNotifier()
+
+		for {
+			ch, source, cancel := o.subscribe(ctx)
+			for value := range ch {
+				emitter.Next(value)
+			}
+			cancel()
+
+			err := source.Err()
+			if err == nil || ctx.Err() != nil {
+				return
+			}
+
+			if !o.cold() {
+				emitter.Error(err)
+				return
+			}
+
+			errs.Next(err)
+			if _, ok := <-notifierCh; !ok {
+				if notifierErr := notifierSource.Err(); notifierErr != nil {
+					emitter.Error(notifierErr)
+				}
+				return
+			}
+		}
+	})
+}
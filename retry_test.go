@@ -0,0 +1,115 @@
+package rx_go_test
+
+import (
+	"context"
+	"errors"
+	"github.com/PxyUp/rx_go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func failingSource(failures int, err error) (*rx_go.Observable[int], *int) {
+	attempts := 0
+	return rx_go.Create(func(ctx context.Context, emitter rx_go.Emitter[int]) {
+		attempts++
+		emitter.Next(attempts)
+		if attempts <= failures {
+			emitter.Error(err)
+		}
+	}), &attempts
+}
+
+func TestRetry(t *testing.T) {
+	obs, attempts := failingSource(2, errors.New("failed"))
+	start := time.Now()
+	ch, _ := rx_go.Retry(obs, rx_go.RetryConfig{
+		MaxAttempts:     5,
+		InitialInterval: time.Millisecond * 50,
+		Multiplier:      2,
+		Jitter:          0.1,
+	}).Subscribe()
+	var res []int
+	for v := range ch {
+		res = append(res, v)
+	}
+	assert.Equal(t, []int{1, 2, 3}, res)
+	assert.Equal(t, 3, *attempts)
+	// 50ms + 100ms with jitter
+	assert.True(t, time.Since(start) >= time.Millisecond*135)
+}
+
+func TestRetry_MaxAttempts(t *testing.T) {
+	err := errors.New("failed")
+	obs, attempts := failingSource(10, err)
+	retry := rx_go.Retry(obs, rx_go.RetryConfig{
+		MaxAttempts: 3,
+	})
+	ch, _ := retry.Subscribe()
+	var res []int
+	for v := range ch {
+		res = append(res, v)
+	}
+	assert.Equal(t, []int{1, 2, 3}, res)
+	assert.Equal(t, 3, *attempts)
+	assert.Equal(t, err, retry.Err())
+}
+
+func TestRetry_DefaultInterval(t *testing.T) {
+	obs, attempts := failingSource(2, errors.New("failed"))
+	start := time.Now()
+	res, retryErr := collectErr(rx_go.Retry(obs, rx_go.RetryConfig{}))
+	assert.Equal(t, []int{1, 2, 3}, res)
+	assert.Equal(t, 3, *attempts)
+	assert.NoError(t, retryErr)
+	assert.True(t, time.Since(start) >= rx_go.DefaultRetryInterval*2)
+}
+
+func TestRetry_ShouldRetry(t *testing.T) {
+	err := errors.New("fatal")
+	obs, attempts := failingSource(10, err)
+	retry := rx_go.Retry(obs, rx_go.RetryConfig{
+		ShouldRetry: func(e error) bool {
+			return e != err
+		},
+	})
+	_, retryErr := collectErr(retry)
+	assert.Equal(t, 1, *attempts)
+	assert.Equal(t, err, retryErr)
+}
+
+func TestRetryWhen(t *testing.T) {
+	obs, attempts := failingSource(10, errors.New("failed"))
+	retry := rx_go.RetryWhen(obs, func(errs *rx_go.Observable[error]) *rx_go.Observable[error] {
+		return errs.Pipe(rx_go.Take[error](2))
+	})
+	res, retryErr := collectErr(retry)
+	assert.Equal(t, []int{1, 2, 3}, res)
+	assert.Equal(t, 3, *attempts)
+	assert.NoError(t, retryErr)
+}
+
+func TestRetryWhen_Error(t *testing.T) {
+	err := errors.New("stop")
+	obs, _ := failingSource(10, errors.New("failed"))
+	retry := rx_go.RetryWhen(obs, func(errs *rx_go.Observable[error]) *rx_go.Observable[int] {
+		return rx_go.Switch(errs, func(e error) *rx_go.Observable[int] {
+			return rx_go.Throw[int](err)
+		})
+	})
+	_, retryErr := collectErr(retry)
+	assert.Equal(t, err, retryErr)
+}
+
+func TestRetry_Hot(t *testing.T) {
+	err := errors.New("failed")
+	res, retryErr := collectErr(rx_go.Retry(rx_go.Throw[int](err), rx_go.RetryConfig{}))
+	assert.Empty(t, res)
+	assert.Equal(t, err, retryErr)
+
+	res, retryErr = collectErr(rx_go.RetryWhen(rx_go.Throw[int](err), func(errs *rx_go.Observable[error]) *rx_go.Observable[error] {
+		return errs
+	}))
+	assert.Empty(t, res)
+	assert.Equal(t, err, retryErr)
+}
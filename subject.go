@@ -0,0 +1,277 @@
+package rx_go
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Subject - hot observable which is also observer, values passed to Next are emitted to all active subscribers
+type Subject[T any] struct {
+	*Observable[T]
+	multicast *multicast[T]
+}
+
+// NewSubject - create new Subject
+func NewSubject[T any]() *Subject[T] {
+	m := newMulticast[T]()
+	return &Subject[T]{
+		Observable: &Observable[T]{
+			factory: func(ctx context.Context) *Observer[T] {
+				return m.subscribe()
+			},
+		},
+		multicast: m,
+	}
+}
+
+// Next - emit value to all active subscribers
+func (s *Subject[T]) Next(value T) {
+	s.multicast.next(value)
+}
+
+// Error - complete all subscribers with error, late subscribers receive error too
+func (s *Subject[T]) Error(err error) {
+	s.multicast.complete(err)
+}
+
+// Complete - complete all subscribers, late subscribers are completed immediately
+func (s *Subject[T]) Complete() {
+	s.multicast.complete(nil)
+}
+
+// Err - return error passed to Error, nil if subject completed without error or still active
+func (s *Subject[T]) Err() error {
+	return s.multicast.terminalErr()
+}
+
+// BehaviorSubject - Subject which has current value, new subscriber receive current value first
+type BehaviorSubject[T any] struct {
+	*Observable[T]
+	multicast *multicast[T]
+
+	mutex     sync.Mutex
+	value     T
+	completed bool
+}
+
+// NewBehaviorSubject - create new BehaviorSubject with initial value
+func NewBehaviorSubject[T any](value T) *BehaviorSubject[T] {
+	s := &BehaviorSubject[T]{
+		multicast: newMulticast[T](),
+		value:     value,
+	}
+	s.Observable = &Observable[T]{
+		factory: func(ctx context.Context) *Observer[T] {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			if s.completed {
+				return s.multicast.subscribe()
+			}
+			return s.multicast.subscribe(s.value)
+		},
+	}
+	return s
+}
+
+// Value - return current value
+func (s *BehaviorSubject[T]) Value() T {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.value
+}
+
+// Next - change current value and emit it to all active subscribers
+func (s *BehaviorSubject[T]) Next(value T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.completed {
+		return
+	}
+	s.value = value
+	s.multicast.next(value)
+}
+
+// Error - complete all subscribers with error, late subscribers receive only error
+func (s *BehaviorSubject[T]) Error(err error) {
+	s.complete(err)
+}
+
+// Complete - complete all subscribers, late subscribers are completed immediately
+func (s *BehaviorSubject[T]) Complete() {
+	s.complete(nil)
+}
+
+// Err - return error passed to Error, nil if subject completed without error or still active
+func (s *BehaviorSubject[T]) Err() error {
+	return s.multicast.terminalErr()
+}
+
+func (s *BehaviorSubject[T]) complete(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.completed = true
+	s.multicast.complete(err)
+}
+
+type replayItem[T any] struct {
+	value T
+	time  time.Time
+}
+
+// ReplaySubject - Subject which replay buffered values to new subscribers
+type ReplaySubject[T any] struct {
+	*Observable[T]
+	multicast *multicast[T]
+
+	size   int
+	window time.Duration
+
+	mutex     sync.Mutex
+	buffer    []replayItem[T]
+	completed bool
+}
+
+// NewReplaySubject - create new ReplaySubject which replay last size values not older than window, zero size or window means no limit
+func NewReplaySubject[T any](size int, window time.Duration) *ReplaySubject[T] {
+	s := &ReplaySubject[T]{
+		multicast: newMulticast[T](),
+		size:      size,
+		window:    window,
+	}
+	s.Observable = &Observable[T]{
+		factory: func(ctx context.Context) *Observer[T] {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			s.trim()
+			values := make([]T, len(s.buffer))
+			for i, item := range s.buffer {
+				values[i] = item.value
+			}
+			return s.multicast.subscribe(values...)
+		},
+	}
+	return s
+}
+
+// Next - buffer value and emit it to all active subscribers
+func (s *ReplaySubject[T]) Next(value T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.completed {
+		return
+	}
+	s.buffer = append(s.buffer, replayItem[T]{
+		value: value,
+		time:  time.Now(),
+	})
+	s.trim()
+	s.multicast.next(value)
+}
+
+// Error - complete all subscribers with error, late subscribers receive buffered values and error
+func (s *ReplaySubject[T]) Error(err error) {
+	s.complete(err)
+}
+
+// Complete - complete all subscribers, late subscribers receive buffered values and completed
+func (s *ReplaySubject[T]) Complete() {
+	s.complete(nil)
+}
+
+// Err - return error passed to Error, nil if subject completed without error or still active
+func (s *ReplaySubject[T]) Err() error {
+	return s.multicast.terminalErr()
+}
+
+func (s *ReplaySubject[T]) complete(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.completed = true
+	s.multicast.complete(err)
+}
+
+// trim - remove values which are out of size or window, should be called under mutex
+func (s *ReplaySubject[T]) trim() {
+	if s.size > 0 && len(s.buffer) > s.size {
+		s.buffer = s.buffer[len(s.buffer)-s.size:]
+	}
+	if s.window > 0 {
+		from := time.Now().Add(-s.window)
+		i := 0
+		for i < len(s.buffer) && s.buffer[i].time.Before(from) {
+			i++
+		}
+		s.buffer = s.buffer[i:]
+	}
+}
+
+// AsyncSubject - Subject which emit only last value and only after completion
+type AsyncSubject[T any] struct {
+	*Observable[T]
+	multicast *multicast[T]
+
+	mutex     sync.Mutex
+	last      *T
+	completed bool
+	err       error
+}
+
+// NewAsyncSubject - create new AsyncSubject
+func NewAsyncSubject[T any]() *AsyncSubject[T] {
+	s := &AsyncSubject[T]{
+		multicast: newMulticast[T](),
+	}
+	s.Observable = &Observable[T]{
+		factory: func(ctx context.Context) *Observer[T] {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			if s.completed && s.err == nil && s.last != nil {
+				return s.multicast.subscribe(*s.last)
+			}
+			return s.multicast.subscribe()
+		},
+	}
+	return s
+}
+
+// Next - remember value, it will be emitted on completion if it is last one
+func (s *AsyncSubject[T]) Next(value T) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.completed {
+		return
+	}
+	s.last = &value
+}
+
+// Error - complete all subscribers with error, last value is not emitted
+func (s *AsyncSubject[T]) Error(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.completed {
+		return
+	}
+	s.completed = true
+	s.err = err
+	s.multicast.complete(err)
+}
+
+// Complete - emit last value to all subscribers and complete them
+func (s *AsyncSubject[T]) Complete() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.completed {
+		return
+	}
+	s.completed = true
+	if s.last != nil {
+		s.multicast.next(*s.last)
+	}
+	s.multicast.complete(nil)
+}
+
+// Err - return error passed to Error, nil if subject completed without error or still active
+func (s *AsyncSubject[T]) Err() error {
+	return s.multicast.terminalErr()
+}
@@ -0,0 +1,111 @@
+package rx_go_test
+
+import (
+	"errors"
+	"github.com/PxyUp/rx_go"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubject(t *testing.T) {
+	s := rx_go.NewSubject[int]()
+	ch1, _ := s.Subscribe()
+	ch2, _ := s.Subscribe()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		go func(value int) {
+			defer wg.Done()
+			s.Next(value)
+		}(i)
+	}
+	wg.Wait()
+	s.Complete()
+
+	res1 := collect(ch1)
+	assert.ElementsMatch(t, []int{0, 1, 2}, res1)
+	assert.Equal(t, res1, collect(ch2))
+
+	ch3, _ := s.Subscribe()
+	assert.Nil(t, collect(ch3))
+}
+
+func TestSubject_Error(t *testing.T) {
+	err := errors.New("failed")
+	s := rx_go.NewSubject[int]()
+	ch, _ := s.Subscribe()
+	s.Next(1)
+	s.Error(err)
+	s.Next(2)
+	assert.Equal(t, []int{1}, collect(ch))
+	assert.Equal(t, err, s.Err())
+}
+
+func TestBehaviorSubject(t *testing.T) {
+	s := rx_go.NewBehaviorSubject(1)
+	ch1, _ := s.Subscribe()
+	s.Next(2)
+	ch2, _ := s.Subscribe()
+	s.Next(3)
+	s.Complete()
+
+	assert.Equal(t, []int{1, 2, 3}, collect(ch1))
+	assert.Equal(t, []int{2, 3}, collect(ch2))
+	assert.Equal(t, 3, s.Value())
+
+	ch3, _ := s.Subscribe()
+	assert.Nil(t, collect(ch3))
+}
+
+func TestReplaySubject(t *testing.T) {
+	s := rx_go.NewReplaySubject[int](2, 0)
+	s.Next(1)
+	s.Next(2)
+	s.Next(3)
+	ch1, _ := s.Subscribe()
+	s.Next(4)
+	s.Complete()
+
+	assert.Equal(t, []int{2, 3, 4}, collect(ch1))
+
+	ch2, _ := s.Subscribe()
+	assert.Equal(t, []int{3, 4}, collect(ch2))
+}
+
+func TestReplaySubject_Window(t *testing.T) {
+	s := rx_go.NewReplaySubject[int](0, time.Millisecond*100)
+	s.Next(1)
+	time.Sleep(time.Millisecond * 150)
+	s.Next(2)
+	s.Complete()
+
+	ch, _ := s.Subscribe()
+	assert.Equal(t, []int{2}, collect(ch))
+}
+
+func TestAsyncSubject(t *testing.T) {
+	s := rx_go.NewAsyncSubject[int]()
+	ch1, _ := s.Subscribe()
+	s.Next(1)
+	s.Next(2)
+	s.Complete()
+
+	assert.Equal(t, []int{2}, collect(ch1))
+
+	ch2, _ := s.Subscribe()
+	assert.Equal(t, []int{2}, collect(ch2))
+}
+
+func TestAsyncSubject_Error(t *testing.T) {
+	err := errors.New("failed")
+	s := rx_go.NewAsyncSubject[int]()
+	ch, _ := s.Subscribe()
+	s.Next(1)
+	s.Error(err)
+
+	assert.Nil(t, collect(ch))
+	assert.Equal(t, err, s.Err())
+}
@@ -0,0 +1,87 @@
+package rx_go
+
+import (
+	"context"
+)
+
+// Handlers - callbacks for SubscribeWith, nil callbacks are ignored
+type Handlers[T any] struct {
+	OnNext     func(value T)
+	OnError    func(err error)
+	OnComplete func()
+}
+
+// Subscription - handle of the subscription created by SubscribeWith
+type Subscription struct {
+	cancel func()
+	done   chan struct{}
+	err    error
+}
+
+// Unsubscribe - cancel subscription, OnError and OnComplete will not be called
+func (s *Subscription) Unsubscribe() {
+	s.cancel()
+}
+
+// Done - return channel which is closed after subscription finished and all handlers were called
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err - return error of the observable, error of the context if subscription was cancelled or PanicError if handler panics, nil if observable completed or subscription still active
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// SubscribeWith - subscribe with callbacks and return subscription handle
+func (o *Observable[T]) SubscribeWith(ctx context.Context, handlers Handlers[T]) *Subscription {
+	ctx, cancel := context.WithCancel(ctx)
+	ch, source, _ := o.subscribe(ctx)
+	o.track(source)
+
+	sub := &Subscription{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(sub.done)
+		defer cancel()
+		defer func() {
+			if r := recover(); r != nil {
+				sub.err = newPanicError(r)
+			}
+		}()
+
+		for value := range ch {
+			if handlers.OnNext != nil {
+				handlers.OnNext(value)
+			}
+		}
+
+		// cancelled source can complete with error of the context, it should not be reported as error of the observable
+		if err := ctx.Err(); err != nil {
+			sub.err = err
+			return
+		}
+
+		if err := source.Err(); err != nil {
+			sub.err = err
+			if handlers.OnError != nil {
+				handlers.OnError(err)
+			}
+			return
+		}
+
+		if handlers.OnComplete != nil {
+			handlers.OnComplete()
+		}
+	}()
+
+	return sub
+}
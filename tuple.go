@@ -0,0 +1,35 @@
+package rx_go
+
+// Tuple2 - pair of values with different types
+type Tuple2[A any, B any] struct {
+	V1 A
+	V2 B
+}
+
+// Tuple3 - three values with different types
+type Tuple3[A any, B any, C any] struct {
+	V1 A
+	V2 B
+	V3 C
+}
+
+// Tuple4 - four values with different types
+type Tuple4[A any, B any, C any, D any] struct {
+	V1 A
+	V2 B
+	V3 C
+	V4 D
+}
+
+// toAny - convert observable into observable of any, used by combinators of observables with different types
+func toAny[T any](o *Observable[T]) *Observable[any] {
+	return MapTo(o, func(value T) any {
+		return value
+	})
+}
+
+// fromAny - convert value back to T, nil is converted into zero value
+func fromAny[T any](value any) T {
+	t, _ := value.(T)
+	return t
+}